@@ -0,0 +1,64 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Avro schemas of the uuid logical type, annotating a string holding the
+// canonical form or, since Avro 1.12, a fixed of 16 bytes.
+const (
+	AvroSchema      = `{"type":"string","logicalType":"uuid"}`
+	AvroFixedSchema = `{"type":"fixed","name":"uuid","size":16,"logicalType":"uuid"}`
+)
+
+// AppendAvro appends uuid to dst in the Avro binary encoding of AvroSchema, a
+// length-prefixed string holding the canonical form, and returns the extended
+// buffer.
+func (uuid UUID) AppendAvro(dst []byte) ([]byte, error) {
+	encoded, err := uuid.AppendText(binary.AppendVarint(dst, 36))
+	if err != nil {
+		return dst, err
+	}
+
+	return encoded, nil
+}
+
+// AppendAvroFixed appends uuid to dst in the Avro binary encoding of
+// AvroFixedSchema, its 16 bytes, and returns the extended buffer.
+func (uuid UUID) AppendAvroFixed(dst []byte) ([]byte, error) {
+	return uuid.AppendBinary(dst)
+}
+
+// DecodeAvro decodes a UUID in the Avro binary encoding of AvroSchema from the
+// start of data and returns it with the number of bytes read. The string may
+// be in any of the forms accepted by Parse.
+func DecodeAvro(data []byte) (UUID, int, error) {
+	length, n := binary.Varint(data)
+	if n <= 0 || length < 0 || length > int64(len(data)-n) {
+		return nil, 0, fmt.Errorf("uuid: invalid Avro string")
+	}
+
+	end := n + int(length)
+	uuid, err := Parse(string(data[n:end]))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return uuid, end, nil
+}
+
+// DecodeAvroFixed decodes a UUID in the Avro binary encoding of
+// AvroFixedSchema from the start of data and returns it with the number of
+// bytes read.
+func DecodeAvroFixed(data []byte) (UUID, int, error) {
+	if len(data) < 16 {
+		return nil, 0, fmt.Errorf("uuid: invalid UUID length %d", len(data))
+	}
+
+	return append(UUID(nil), data[:16]...), 16, nil
+}
@@ -0,0 +1,47 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAvro(t *testing.T) {
+	data, err := NamespaceDNS.AppendAvro([]byte{0xFF})
+	expected := append([]byte{0xFF, 0x48}, NamespaceDNS.String()...)
+	if err != nil || !bytes.Equal(data, expected) {
+		t.Errorf("Failed to encode Avro string. Expected: %x, Received: %x",
+			expected, data)
+	}
+
+	// a record of two fields, the UUID and a trailing byte
+	data = append(data[1:], 0x7F)
+	result, n, err := DecodeAvro(data)
+	if err != nil || !result.Equal(NamespaceDNS) || n != 37 {
+		t.Errorf("Failed to decode Avro string. Expected: %s, Received: %s "+
+			"(%d bytes)", NamespaceDNS, result, n)
+	}
+
+	for _, input := range [][]byte{nil, {0x48, '6'}, {0x01},
+		append([]byte{0x08}, "abcd"...)} {
+		if _, _, err := DecodeAvro(input); err == nil {
+			t.Errorf("decoded invalid Avro string %x", input)
+		}
+	}
+}
+
+func TestAvroFixed(t *testing.T) {
+	data, err := NamespaceDNS.AppendAvroFixed(nil)
+	if err != nil || !bytes.Equal(data, NamespaceDNS) {
+		t.Errorf("Failed to encode Avro fixed. Expected: %x, Received: %x",
+			[]byte(NamespaceDNS), data)
+	}
+
+	result, n, err := DecodeAvroFixed(append(data, 0x7F))
+	if err != nil || !result.Equal(NamespaceDNS) || n != 16 {
+		t.Errorf("Failed to decode Avro fixed. Expected: %s, Received: %s "+
+			"(%d bytes)", NamespaceDNS, result, n)
+	}
+	if _, _, err := DecodeAvroFixed(data[:15]); err == nil {
+		t.Errorf("decoded 15 bytes")
+	}
+}
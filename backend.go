@@ -0,0 +1,78 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Backend generates Version 4 UUIDs outside of the Generator, e.g. by
+// delegating to the operating system. When a Backend is set and fails, the
+// Generator falls back to generating the UUID in process.
+type Backend interface {
+	NewV4() (UUID, error)
+}
+
+// kernelUUIDPath is the Linux procfs file returning a new random UUID each
+// time it is read.
+const kernelUUIDPath = "/proc/sys/kernel/random/uuid"
+
+// NewKernelBackend returns a Backend that reads Version 4 UUIDs generated by
+// the Linux kernel from /proc/sys/kernel/random/uuid, for deployments whose
+// policy requires UUID creation to be delegated to the kernel. On other
+// platforms every call fails and UUIDs are generated in process.
+func NewKernelBackend() Backend {
+	return kernelBackend{}
+}
+
+type kernelBackend struct{}
+
+func (kernelBackend) NewV4() (UUID, error) {
+	content, err := os.ReadFile(kernelUUIDPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := Parse(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("uuid: reading %s: %w", kernelUUIDPath, err)
+	}
+
+	return result, nil
+}
+
+// SetBackend sets the Backend used by NewV4 and NewV4E. Passing nil restores
+// in-process generation, which is the default.
+func SetBackend(backend Backend) {
+	Default().SetBackend(backend)
+}
+
+// SetBackend sets the Backend used by g to generate Version 4 UUIDs. Passing
+// nil restores in-process generation.
+func (g *Generator) SetBackend(backend Backend) {
+	if backend == nil {
+		g.backend.Store(nil)
+		return
+	}
+	g.backend.Store(&backend)
+}
+
+// backendV4 returns a UUID from the Backend of g, if one is set and succeeds.
+func (g *Generator) backendV4() (UUID, bool) {
+	backend := g.backend.Load()
+	if backend == nil {
+		return nil, false
+	}
+
+	result, err := (*backend).NewV4()
+	if err != nil {
+		g.backendErrors.Add(1)
+		return nil, false
+	}
+
+	return result, true
+}
@@ -0,0 +1,24 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package uuid
+
+import (
+	"errors"
+)
+
+// NewCoCreateGuidBackend returns a Backend that generates UUIDs with the
+// Windows CoCreateGuid API. CoCreateGuid is only available on Windows, so
+// elsewhere every call fails and UUIDs are generated in process.
+func NewCoCreateGuidBackend() Backend {
+	return coCreateGuidBackend{}
+}
+
+type coCreateGuidBackend struct{}
+
+func (coCreateGuidBackend) NewV4() (UUID, error) {
+	return nil, errors.New("uuid: CoCreateGuid is only available on Windows")
+}
@@ -0,0 +1,117 @@
+package uuid
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+)
+
+// failingBackend always fails, forcing in-process generation.
+type failingBackend struct{}
+
+func (failingBackend) NewV4() (UUID, error) {
+	return nil, errors.New("backend unavailable")
+}
+
+func TestKernelBackend(t *testing.T) {
+	if _, err := os.Stat(kernelUUIDPath); err != nil {
+		t.Skipf("%s not available: %v", kernelUUIDPath, err)
+	}
+
+	result, err := NewKernelBackend().NewV4()
+	if err != nil {
+		t.Fatalf("failed to read kernel UUID: %v", err)
+	}
+	if result.Version() != Version4 || result.Variant() != VariantRFC4122 {
+		t.Errorf("incorrect version or variant detected: %s", result)
+	}
+
+	SetBackend(NewKernelBackend())
+	defer SetBackend(nil)
+	if a, b := NewV4(), NewV4(); a.Equal(b) {
+		t.Errorf("Duplicate UUIDs detected")
+	}
+}
+
+func TestSetBackendFallback(t *testing.T) {
+	SetBackend(failingBackend{})
+	defer SetBackend(nil)
+
+	result, err := NewV4E()
+	if err != nil {
+		t.Fatalf("failed to fall back to in-process generation: %v", err)
+	}
+	if result.Version() != Version4 {
+		t.Errorf("incorrect version detected: %s", result.Version())
+	}
+
+	g := NewGenerator(WithBackend(failingBackend{}))
+	if backend := g.Health().Backend; backend != "uuid.failingBackend" {
+		t.Errorf("Failed to set backend. Expected: uuid.failingBackend, "+
+			"Received: %s", backend)
+	}
+	g.NewV4()
+	g.NewV4()
+	if errors := g.Health().BackendErrors; errors != 2 {
+		t.Errorf("Failed to count backend errors. Expected: 2, Received: %d",
+			errors)
+	}
+}
+
+func TestCoCreateGuidBackend(t *testing.T) {
+	result, err := NewCoCreateGuidBackend().NewV4()
+	if runtime.GOOS != "windows" {
+		if err == nil {
+			t.Errorf("generated a CoCreateGuid UUID on %s", runtime.GOOS)
+		}
+		return
+	}
+
+	if err != nil {
+		t.Fatalf("failed to call CoCreateGuid: %v", err)
+	}
+	if result.Version() != Version4 || result.Variant() != VariantRFC4122 {
+		t.Errorf("incorrect version or variant detected: %s", result)
+	}
+}
+
+// limitedBackend issues remaining UUIDs and then fails.
+type limitedBackend struct {
+	remaining int
+	issued    []UUID
+}
+
+func (b *limitedBackend) NewV4() (UUID, error) {
+	if b.remaining == 0 {
+		return nil, errors.New("backend exhausted")
+	}
+	b.remaining--
+	result := NewV4()
+	b.issued = append(b.issued, result)
+
+	return result, nil
+}
+
+func TestAppendBatchBackend(t *testing.T) {
+	backend := &limitedBackend{remaining: 3}
+	g := NewGenerator(WithBackend(backend))
+
+	batch, err := g.AppendBatch(nil, Version4, 5)
+	if err != nil || len(batch) != 5 {
+		t.Fatalf("failed to generate batch: %d, %v", len(batch), err)
+	}
+	for i, result := range batch {
+		if result.Version() != Version4 {
+			t.Errorf("incorrect version detected: %s", result.Version())
+		}
+		if i < len(backend.issued) && !result.Equal(backend.issued[i]) {
+			t.Errorf("Failed to use backend. Expected: %s, Received: %s",
+				backend.issued[i], result)
+		}
+	}
+	if len(backend.issued) != 3 || g.Health().BackendErrors != 1 {
+		t.Errorf("Failed to fall back after the backend failed: %d issued, "+
+			"%d errors", len(backend.issued), g.Health().BackendErrors)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var procCoCreateGuid = syscall.NewLazyDLL("ole32.dll").NewProc("CoCreateGuid")
+
+// NewCoCreateGuidBackend returns a Backend that generates UUIDs with the
+// Windows CoCreateGuid API, so they match those produced by other native
+// components on the host.
+func NewCoCreateGuidBackend() Backend {
+	return coCreateGuidBackend{}
+}
+
+type coCreateGuidBackend struct{}
+
+func (coCreateGuidBackend) NewV4() (UUID, error) {
+	if err := procCoCreateGuid.Find(); err != nil {
+		return nil, err
+	}
+
+	var guid GUID
+	hr, _, _ := procCoCreateGuid.Call(uintptr(unsafe.Pointer(&guid)))
+	if hr != 0 {
+		return nil, fmt.Errorf("uuid: CoCreateGuid failed with HRESULT "+
+			"0x%08X", uint32(hr))
+	}
+
+	return FromGUID(guid), nil
+}
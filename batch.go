@@ -0,0 +1,112 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+)
+
+// NewV1Batch generates n Version 1 UUIDs with consecutive timestamps under a
+// single lock acquisition and clock read. Returns nil if n is not positive.
+// Panics if n is larger than the timestamp counter of 10000 UUIDs, or if the
+// counter is exhausted under CounterReturnError.
+func NewV1Batch(n int) []UUID {
+	return mustBatch(Default().AppendBatch(nil, Version1, n))
+}
+
+// NewV4Batch generates n Version 4 UUIDs from a single read of the random
+// source, or from the Backend if one is set. Returns nil if n is not positive. Panics if the random source
+// fails.
+func NewV4Batch(n int) []UUID {
+	return mustBatch(Default().AppendBatch(nil, Version4, n))
+}
+
+// AppendBatch appends n UUIDs of the given version to dst and returns the
+// extended slice, see Generator.AppendBatch.
+func AppendBatch(dst []UUID, version Version, n int) ([]UUID, error) {
+	return Default().AppendBatch(dst, version, n)
+}
+
+// AppendBatch appends n UUIDs of the given version to dst and returns the
+// extended slice. Locking, clock reads and random reads are amortized across
+// the batch: the time-based versions take a single lock acquisition and
+// clock read, and the random bits of the whole batch are read at once into a
+// single allocation. Version 1, 4, 6 and 7 are supported. If a Backend is
+// set Version 4 UUIDs are taken from it one at a time, and the rest of the
+// batch is generated in process once it fails.
+func (g *Generator) AppendBatch(dst []UUID, version Version, n int) ([]UUID, error) {
+	if n <= 0 {
+		return dst, nil
+	}
+
+	switch version {
+	case Version1, Version6:
+		newTime, clockSequence, node, err := g.reserveTimestamps(n)
+		if err != nil {
+			return dst, err
+		}
+		g.countGenerated(version, n)
+		layout := newV1
+		if version == Version6 {
+			layout = newV6
+		}
+		for i := 0; i < n; i++ {
+			dst = append(dst, layout(newTime+uint64(i), clockSequence, node))
+		}
+		return dst, nil
+	case Version4, Version7:
+		if version == Version4 && g.backend.Load() != nil {
+			for ; n > 0; n-- {
+				result, ok := g.backendV4()
+				if !ok {
+					break
+				}
+				g.countGenerated(Version4, 1)
+				dst = append(dst, result)
+			}
+			if n == 0 {
+				return dst, nil
+			}
+		}
+
+		var timestamp uint64
+		if version == Version7 {
+			var err error
+			if timestamp, err = g.reserveV7(n); err != nil {
+				return dst, err
+			}
+		}
+
+		// allocate and randomize the UUIDs in one block
+		block := make([]byte, 16*n)
+		if err := g.readRandom(block); err != nil {
+			return dst, err
+		}
+		g.countGenerated(version, n)
+
+		for i := 0; i < n; i++ {
+			result := UUID(block[i*16 : i*16+16 : i*16+16])
+			if version == Version7 {
+				putV7Timestamp(result, timestamp+uint64(i))
+			} else {
+				result[6] = (result[6] & 0x0F) | 0x40 // version 4
+			}
+			result[8] = (result[8] & 0x3F) | 0x80 // RFC 4122 variant
+			dst = append(dst, result)
+		}
+		return dst, nil
+	}
+
+	return dst, fmt.Errorf("uuid: batches of %s are not supported", version)
+}
+
+// mustBatch returns batch if err is nil and panics otherwise.
+func mustBatch(batch []UUID, err error) []UUID {
+	if err != nil {
+		panic(err)
+	}
+
+	return batch
+}
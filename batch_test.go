@@ -0,0 +1,69 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestAppendBatch(t *testing.T) {
+	for _, version := range []Version{Version1, Version4, Version6, Version7} {
+		dst := []UUID{Nil}
+		batch, err := AppendBatch(dst, version, 1000)
+		if err != nil {
+			t.Fatalf("failed to generate %s batch: %v", version, err)
+		}
+		if len(batch) != 1001 || !batch[0].IsNil() {
+			t.Fatalf("Failed to append %s batch. Expected: %d, Received: %d",
+				version, 1001, len(batch))
+		}
+
+		seen := make(map[string]bool)
+		for i, result := range batch[1:] {
+			if result.Version() != version || result.Variant() != VariantRFC4122 {
+				t.Fatalf("incorrect version or variant detected on test %d: %s",
+					i, result)
+			}
+			if seen[result.String()] {
+				t.Fatalf("Duplicate UUIDs detected on test %d", i)
+			}
+			seen[result.String()] = true
+		}
+	}
+
+	if _, err := AppendBatch(nil, Version5, 10); err == nil {
+		t.Errorf("generated a batch of an unsupported version")
+	}
+}
+
+func TestNewV1Batch(t *testing.T) {
+	batch := NewV1Batch(100)
+	if len(batch) != 100 {
+		t.Fatalf("incorrect batch size. Expected: %d, Received: %d", 100,
+			len(batch))
+	}
+
+	// consecutive timestamps sort in order once converted to Version 6
+	for i := 1; i < len(batch); i++ {
+		if Compare(V1ToV6(batch[i-1]), V1ToV6(batch[i])) >= 0 {
+			t.Fatalf("timestamp out of order on test %d", i)
+		}
+	}
+	if last, next := V1ToV6(batch[99]), V1ToV6(NewV1()); Compare(last[:8], next[:8]) > 0 {
+		t.Errorf("UUID after batch out of order: %s > %s", last, next)
+	}
+}
+
+func TestNewV4Batch(t *testing.T) {
+	if batch := NewV4Batch(100); len(batch) != 100 {
+		t.Errorf("incorrect batch size. Expected: %d, Received: %d", 100,
+			len(batch))
+	}
+	if NewV4Batch(0) != nil {
+		t.Errorf("returned a batch for n = 0")
+	}
+}
+
+func BenchmarkNewV4Batch(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewV4Batch(1000)
+	}
+}
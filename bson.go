@@ -0,0 +1,140 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// BSON element types and binary subtypes, see https://bsonspec.org/spec.html.
+const (
+	bsonString = 0x02
+	bsonBinary = 0x05
+	bsonNull   = 0x0A
+
+	// BSONSubtypeUUID is the BSON binary subtype of UUIDs.
+	BSONSubtypeUUID = 0x04
+	// BSONSubtypeLegacyUUID is the BSON binary subtype of UUIDs written by
+	// older MongoDB drivers, in a byte order that depended on the driver,
+	// see SetBSONLegacySubtype.
+	BSONSubtypeLegacyUUID = 0x03
+)
+
+// bsonLegacy makes MarshalBSONValue write subtype 3, see
+// SetBSONLegacySubtype.
+var bsonLegacy atomic.Bool
+
+// SetBSONLegacySubtype selects whether MarshalBSONValue writes UUIDs with the
+// legacy binary subtype 3 instead of subtype 4, for collections shared with
+// applications still reading subtype 3. The bytes are written in canonical
+// order, the order of the Python driver, see ToBSONLegacy for the others.
+// Disabled by default.
+func SetBSONLegacySubtype(enabled bool) {
+	bsonLegacy.Store(enabled)
+}
+
+// MarshalBSONValue returns uuid as a BSON binary value of subtype 4,
+// implementing the bson.ValueMarshaler interface of version 2 of the MongoDB
+// Go driver, so UUID fields are stored as 16 bytes rather than strings.
+// Version 1 of the driver declares the type as bsontype.Type and does not
+// accept this signature. A nil UUID is written as BSON null.
+func (uuid UUID) MarshalBSONValue() (byte, []byte, error) {
+	if uuid == nil {
+		return bsonNull, nil, nil
+	}
+	if len(uuid) != 16 {
+		return 0, nil, fmt.Errorf("uuid: invalid UUID length %d", len(uuid))
+	}
+
+	subtype := byte(BSONSubtypeUUID)
+	if bsonLegacy.Load() {
+		subtype = BSONSubtypeLegacyUUID
+	}
+	data := binary.LittleEndian.AppendUint32(make([]byte, 0, 21), 16)
+	data = append(data, subtype)
+
+	return bsonBinary, append(data, uuid...), nil
+}
+
+// UnmarshalBSONValue decodes a BSON binary value of subtype 4 or 3, a string
+// in any of the forms accepted by Parse, or null, which decodes to a nil
+// UUID, implementing the bson.ValueUnmarshaler interface of version 2 of the
+// MongoDB Go driver.
+// Subtype 3 bytes are taken as they are stored, see FromBSONLegacy for data
+// written in a driver-specific byte order.
+func (uuid *UUID) UnmarshalBSONValue(typ byte, data []byte) error {
+	switch typ {
+	case bsonNull:
+		*uuid = nil
+		return nil
+	case bsonString:
+		if len(data) < 5 || data[len(data)-1] != 0 ||
+			binary.LittleEndian.Uint32(data) != uint32(len(data)-4) {
+			return fmt.Errorf("uuid: invalid BSON string")
+		}
+		return uuid.UnmarshalText(data[4 : len(data)-1])
+	case bsonBinary:
+		if len(data) != 21 || binary.LittleEndian.Uint32(data) != 16 {
+			return fmt.Errorf("uuid: invalid BSON binary UUID length")
+		}
+		if subtype := data[4]; subtype != BSONSubtypeUUID &&
+			subtype != BSONSubtypeLegacyUUID {
+			return fmt.Errorf("uuid: invalid BSON binary subtype %d", subtype)
+		}
+		return uuid.UnmarshalBinary(data[5:])
+	}
+
+	return fmt.Errorf("uuid: cannot decode BSON type %#x into UUID", typ)
+}
+
+// LegacyByteOrder is the byte order in which an older MongoDB driver wrote
+// UUIDs with binary subtype 3.
+type LegacyByteOrder int
+
+const (
+	// LegacyPython is the order of the Python driver, the canonical order.
+	LegacyPython LegacyByteOrder = iota
+	// LegacyJava is the order of the Java driver, with the bytes of each
+	// half of the UUID reversed.
+	LegacyJava
+	// LegacyCSharp is the order of the C# driver, that of .NET GUIDs, with
+	// the first three fields little-endian.
+	LegacyCSharp
+)
+
+// ToBSONLegacy returns the 16 bytes of uuid in the given legacy byte order,
+// to be written with BSONSubtypeLegacyUUID for applications still reading
+// subtype 3. Panics if uuid is not 16 bytes long.
+func (uuid UUID) ToBSONLegacy(order LegacyByteOrder) []byte {
+	return convertLegacy(uuid.Array(), order)
+}
+
+// FromBSONLegacy converts the 16 bytes of a subtype 3 binary value written in
+// the given legacy byte order into a UUID, so identifiers from old datasets
+// can be normalized to subtype 4.
+func FromBSONLegacy(b []byte, order LegacyByteOrder) (UUID, error) {
+	if len(b) != 16 {
+		return nil, fmt.Errorf("uuid: invalid UUID length %d", len(b))
+	}
+
+	return convertLegacy([16]byte(b), order), nil
+}
+
+// convertLegacy converts b between the canonical and the given legacy byte
+// order. Each conversion is its own inverse.
+func convertLegacy(b [16]byte, order LegacyByteOrder) UUID {
+	switch order {
+	case LegacyJava:
+		slices.Reverse(b[:8])
+		slices.Reverse(b[8:])
+	case LegacyCSharp:
+		return swapGUIDFields(b)
+	}
+
+	return b[:]
+}
@@ -0,0 +1,93 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarshalBSONValue(t *testing.T) {
+	typ, data, err := NamespaceDNS.MarshalBSONValue()
+	expected := append([]byte{16, 0, 0, 0, BSONSubtypeUUID}, NamespaceDNS...)
+	if err != nil || typ != bsonBinary || !bytes.Equal(data, expected) {
+		t.Errorf("Failed to marshal BSON. Expected: %x, Received: %#x %x",
+			expected, typ, data)
+	}
+
+	var result UUID
+	if err := result.UnmarshalBSONValue(typ, data); err != nil ||
+		!result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to unmarshal BSON. Expected: %s, Received: %s",
+			NamespaceDNS, result)
+	}
+
+	SetBSONLegacySubtype(true)
+	defer SetBSONLegacySubtype(false)
+	_, data, _ = NamespaceDNS.MarshalBSONValue()
+	if data[4] != BSONSubtypeLegacyUUID {
+		t.Errorf("Failed to marshal legacy subtype. Expected: 3, Received: %d",
+			data[4])
+	}
+
+	if typ, data, err := UUID(nil).MarshalBSONValue(); err != nil ||
+		typ != bsonNull || data != nil {
+		t.Errorf("Failed to marshal nil UUID as null: %#x %x", typ, data)
+	}
+}
+
+func TestUnmarshalBSONValue(t *testing.T) {
+	text := NamespaceDNS.String()
+	str := append([]byte{byte(len(text) + 1), 0, 0, 0}, text...)
+	str = append(str, 0)
+
+	var result UUID
+	if err := result.UnmarshalBSONValue(bsonString, str); err != nil ||
+		!result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to unmarshal BSON string. Expected: %s, Received: %s",
+			NamespaceDNS, result)
+	}
+	if err := result.UnmarshalBSONValue(bsonNull, nil); err != nil ||
+		result != nil {
+		t.Errorf("Failed to unmarshal BSON null. Received: %s", result)
+	}
+
+	invalid := [][]byte{
+		append([]byte{16, 0, 0, 0, 0x00}, NamespaceDNS...),
+		append([]byte{15, 0, 0, 0, BSONSubtypeUUID}, NamespaceDNS[:15]...),
+		str[:len(str)-1],
+	}
+	for i, data := range invalid {
+		typ := byte(bsonBinary)
+		if i == 2 {
+			typ = bsonString
+		}
+		if err := result.UnmarshalBSONValue(typ, data); err == nil {
+			t.Errorf("unmarshaled invalid BSON value %x", data)
+		}
+	}
+	if err := result.UnmarshalBSONValue(0x10, []byte{1, 0, 0, 0}); err == nil {
+		t.Errorf("unmarshaled a BSON int32")
+	}
+}
+
+func TestBSONLegacy(t *testing.T) {
+	expected := map[LegacyByteOrder]string{
+		LegacyPython: "6ba7b8109dad11d180b400c04fd430c8",
+		LegacyJava:   "d111ad9d10b8a76bc830d44fc000b480",
+		LegacyCSharp: "10b8a76bad9dd11180b400c04fd430c8",
+	}
+	for order, want := range expected {
+		b := NamespaceDNS.ToBSONLegacy(order)
+		if s := UUID(b).FormatAs(FormatHex); s != want {
+			t.Errorf("Failed to convert to legacy order %d. Expected: %s, "+
+				"Received: %s", order, want, s)
+		}
+		result, err := FromBSONLegacy(b, order)
+		if err != nil || !result.Equal(NamespaceDNS) {
+			t.Errorf("Failed to convert from legacy order %d. Expected: %s, "+
+				"Received: %s", order, NamespaceDNS, result)
+		}
+	}
+	if _, err := FromBSONLegacy(NamespaceDNS[:15], LegacyJava); err == nil {
+		t.Errorf("converted 15 bytes")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ToMSSQLBytes returns uuid in the byte order of SQL Server uniqueidentifier
+// columns, which store the first three fields little-endian, as Windows
+// GUIDs do. Panics if uuid is not 16 bytes long.
+func (uuid UUID) ToMSSQLBytes() []byte {
+	return swapGUIDFields(uuid.Array())
+}
+
+// FromMSSQLBytes converts the 16 bytes of a SQL Server uniqueidentifier, as
+// read from the database driver, into a UUID whose canonical form matches the
+// one SQL Server prints.
+func FromMSSQLBytes(b []byte) (UUID, error) {
+	if len(b) != 16 {
+		return nil, fmt.Errorf("uuid: invalid UUID length %d", len(b))
+	}
+
+	return swapGUIDFields([16]byte(b)), nil
+}
+
+// GUID is the layout of the Windows GUID structure, with the same fields as
+// syscall.GUID and golang.org/x/sys/windows.GUID, so a *GUID can be passed
+// to Windows APIs through syscall and converted to those types directly.
+type GUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// ToGUID returns uuid as a Windows GUID structure. The first three fields
+// hold the integers of the big-endian canonical layout, so the GUID prints
+// the same as uuid. Panics if uuid is not 16 bytes long.
+func (uuid UUID) ToGUID() GUID {
+	array := uuid.Array()
+
+	guid := GUID{
+		Data1: binary.BigEndian.Uint32(array[0:4]),
+		Data2: binary.BigEndian.Uint16(array[4:6]),
+		Data3: binary.BigEndian.Uint16(array[6:8]),
+	}
+	copy(guid.Data4[:], array[8:])
+
+	return guid
+}
+
+// FromGUID returns the UUID of a Windows GUID structure, the inverse of
+// ToGUID.
+func FromGUID(guid GUID) UUID {
+	uuid := make(UUID, 16)
+	binary.BigEndian.PutUint32(uuid[0:4], guid.Data1)
+	binary.BigEndian.PutUint16(uuid[4:6], guid.Data2)
+	binary.BigEndian.PutUint16(uuid[6:8], guid.Data3)
+	copy(uuid[8:], guid.Data4[:])
+
+	return uuid
+}
+
+// swapGUIDFields reverses the byte order of the 32-bit and two 16-bit fields
+// at the start of b, converting between the big-endian layout of RFC 4122
+// and the mixed-endian layout of Microsoft GUIDs.
+func swapGUIDFields(b [16]byte) UUID {
+	b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+	b[4], b[5] = b[5], b[4]
+	b[6], b[7] = b[7], b[6]
+
+	return b[:]
+}
+
+// ToOracleRaw returns the bytes of uuid in the order Oracle RAW(16) columns
+// store them, which is the canonical order, so RAWTOHEX of the column matches
+// OracleHex. Panics if uuid is not 16 bytes long.
+func (uuid UUID) ToOracleRaw() []byte {
+	array := uuid.Array()
+	return array[:]
+}
+
+// FromOracleRaw converts the 16 bytes of an Oracle RAW(16) column into a UUID.
+// Values generated by SYS_GUID are not RFC 4122 UUIDs, their version and
+// variant bits are arbitrary, so they are not checked; see Version and
+// Variant where that matters.
+func FromOracleRaw(b []byte) (UUID, error) {
+	if len(b) != 16 {
+		return nil, fmt.Errorf("uuid: invalid UUID length %d", len(b))
+	}
+
+	return append(UUID(nil), b...), nil
+}
+
+// OracleHex returns the 32 uppercase hex digits of uuid, the form returned by
+// RAWTOHEX and SYS_GUID in PL/SQL and accepted by HEXTORAW.
+func (uuid UUID) OracleHex() string {
+	array := uuid.Array()
+	return string(appendHex(make([]byte, 0, 32), array[:], upperHexDigits,
+		false))
+}
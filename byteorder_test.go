@@ -0,0 +1,77 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestMSSQLBytes(t *testing.T) {
+	expected := []byte{0x10, 0xB8, 0xA7, 0x6B, 0xAD, 0x9D, 0xD1, 0x11,
+		0x80, 0xB4, 0x00, 0xC0, 0x4F, 0xD4, 0x30, 0xC8}
+	if b := NamespaceDNS.ToMSSQLBytes(); !bytes.Equal(b, expected) {
+		t.Errorf("Failed to convert to SQL Server bytes. Expected: %x, "+
+			"Received: %x", expected, b)
+	}
+
+	result, err := FromMSSQLBytes(expected)
+	if err != nil || !result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to convert from SQL Server bytes. Expected: %s, "+
+			"Received: %s", NamespaceDNS, result)
+	}
+	if expected[0] != 0x10 {
+		t.Errorf("conversion modified its input")
+	}
+	if _, err := FromMSSQLBytes(expected[:15]); err == nil {
+		t.Errorf("converted 15 bytes")
+	}
+}
+
+func TestOracleRaw(t *testing.T) {
+	if b := NamespaceDNS.ToOracleRaw(); !bytes.Equal(b, NamespaceDNS) {
+		t.Errorf("Failed to convert to Oracle RAW. Expected: %x, Received: %x",
+			[]byte(NamespaceDNS), b)
+	}
+	if s := NamespaceDNS.OracleHex(); s != "6BA7B8109DAD11D180B400C04FD430C8" {
+		t.Errorf("Failed to format Oracle hex. Expected: "+
+			"6BA7B8109DAD11D180B400C04FD430C8, Received: %s", s)
+	}
+
+	// a SYS_GUID value, which has no RFC 4122 version or variant
+	raw := []byte{0x2F, 0x6F, 0x1C, 0x0E, 0x4B, 0x3A, 0x37, 0x2E,
+		0xE0, 0x63, 0x0A, 0x00, 0x0A, 0x0A, 0x6F, 0x2C}
+	result, err := FromOracleRaw(raw)
+	if err != nil || !bytes.Equal(result, raw) ||
+		result.OracleHex() != "2F6F1C0E4B3A372EE0630A000A0A6F2C" {
+		t.Errorf("Failed to convert from Oracle RAW. Expected: %x, "+
+			"Received: %x", raw, []byte(result))
+	}
+	if _, err := FromOracleRaw(raw[:15]); err == nil {
+		t.Errorf("converted 15 bytes")
+	}
+}
+
+func TestGUID(t *testing.T) {
+	guid := NamespaceDNS.ToGUID()
+	expected := GUID{Data1: 0x6ba7b810, Data2: 0x9dad, Data3: 0x11d1,
+		Data4: [8]byte{0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}}
+	if guid != expected {
+		t.Errorf("Failed to convert to GUID. Expected: %+v, Received: %+v",
+			expected, guid)
+	}
+	if result := FromGUID(guid); !result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to convert from GUID. Expected: %s, Received: %s",
+			NamespaceDNS, result)
+	}
+
+	// in memory on little-endian machines the fields are in SQL Server order
+	var memory []byte
+	memory = binary.LittleEndian.AppendUint32(memory, guid.Data1)
+	memory = binary.LittleEndian.AppendUint16(memory, guid.Data2)
+	memory = binary.LittleEndian.AppendUint16(memory, guid.Data3)
+	memory = append(memory, guid.Data4[:]...)
+	if !bytes.Equal(memory, NamespaceDNS.ToMSSQLBytes()) {
+		t.Errorf("GUID memory layout %x does not match the mixed-endian layout",
+			memory)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"cmp"
+	"time"
+)
+
+// CompareTimeUUID compares two Version 1 UUIDs as the Cassandra timeuuid type
+// orders them: by their 60-bit timestamp, then by the clock sequence and node
+// bytes compared as signed bytes. It has the signature expected by
+// slices.SortFunc; UUIDs generated by NewV1 and NewV1Batch are valid
+// timeuuids. A nil UUID compares as the Nil UUID. Panics if a or b is not
+// 16 bytes long.
+func CompareTimeUUID(a, b UUID) int {
+	x, y := a.Array(), b.Array()
+	if c := cmp.Compare(v1Timestamp(x), v1Timestamp(y)); c != 0 {
+		return c
+	}
+	for i := 8; i < 16; i++ {
+		if c := cmp.Compare(int8(x[i]), int8(y[i])); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// MinTimeUUID returns the smallest timeuuid of the millisecond of t, like the
+// minTimeuuid function of CQL, as the lower bound of a slice query over a
+// timeuuid column. It is not a valid RFC 4122 UUID and must not be stored.
+func MinTimeUUID(t time.Time) UUID {
+	return timeUUIDBound(epochDiffNanos100s+uint64(t.UnixMilli())*10000, 0x80)
+}
+
+// MaxTimeUUID returns the largest timeuuid of the millisecond of t, like the
+// maxTimeuuid function of CQL, as the upper bound of a slice query over a
+// timeuuid column. It is not a valid RFC 4122 UUID and must not be stored.
+func MaxTimeUUID(t time.Time) UUID {
+	return timeUUIDBound(epochDiffNanos100s+uint64(t.UnixMilli()+1)*10000-1,
+		0x7F)
+}
+
+// timeUUIDBound returns a Version 1 layout of timestamp whose clock sequence
+// and node bytes are all fill.
+func timeUUIDBound(timestamp uint64, fill byte) UUID {
+	uuid := newV1(timestamp, 0, make([]byte, 6))
+	for i := 8; i < 16; i++ {
+		uuid[i] = fill
+	}
+
+	return uuid
+}
+
+// v1Timestamp returns the 60-bit timestamp of a Version 1 UUID.
+func v1Timestamp(uuid [16]byte) uint64 {
+	return uint64(uuid[6]&0x0F)<<56 | uint64(uuid[7])<<48 |
+		uint64(uuid[4])<<40 | uint64(uuid[5])<<32 | uint64(uuid[0])<<24 |
+		uint64(uuid[1])<<16 | uint64(uuid[2])<<8 | uint64(uuid[3])
+}
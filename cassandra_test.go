@@ -0,0 +1,53 @@
+package uuid
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCompareTimeUUID(t *testing.T) {
+	at := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	// late has a time_low of zero, so it sorts before early lexicographically
+	early := NewV1At(at)
+	late := NewV1At(at.Add((1<<32 - 0xC232AB00) * 100 * time.Nanosecond))
+	if Compare(late, early) != -1 {
+		t.Fatalf("lexicographic order of %s and %s is not reversed", early, late)
+	}
+
+	if CompareTimeUUID(early, late) != -1 || CompareTimeUUID(late, early) != 1 ||
+		CompareTimeUUID(early, early) != 0 {
+		t.Errorf("Failed to order timeuuids by timestamp: %s, %s", early, late)
+	}
+
+	a, b := NewV1At(at), NewV1At(at)
+	copy(a[8:], []byte{0x80, 0x00, 0, 0, 0, 0, 0, 0})
+	copy(b[8:], []byte{0x7F, 0x00, 0, 0, 0, 0, 0, 0})
+	if CompareTimeUUID(a, b) != -1 {
+		t.Errorf("Failed to compare clock sequence as signed bytes: %s, %s",
+			a, b)
+	}
+}
+
+func TestTimeUUIDBounds(t *testing.T) {
+	at := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	lower, upper := MinTimeUUID(at), MaxTimeUUID(at)
+	if s := lower.String(); s != "c232ab00-9414-11ec-8080-808080808080" {
+		t.Errorf("Failed to create lower bound. Expected: "+
+			"c232ab00-9414-11ec-8080-808080808080, Received: %s", s)
+	}
+	if s := upper.String(); s != "c232d20f-9414-11ec-7f7f-7f7f7f7f7f7f" {
+		t.Errorf("Failed to create upper bound. Expected: "+
+			"c232d20f-9414-11ec-7f7f-7f7f7f7f7f7f, Received: %s", s)
+	}
+
+	g := NewGenerator(WithClock(FrozenClock(at.Add(500 * time.Microsecond))))
+	list := append(mustBatch(g.AppendBatch(nil, Version1, 100)), lower, upper)
+	slices.SortFunc(list, CompareTimeUUID)
+	if !list[0].Equal(lower) || !list[len(list)-1].Equal(upper) {
+		t.Errorf("Failed to bound the timeuuids of a millisecond")
+	}
+	if CompareTimeUUID(upper, MinTimeUUID(at.Add(time.Millisecond))) != -1 {
+		t.Errorf("bounds of consecutive milliseconds overlap")
+	}
+}
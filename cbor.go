@@ -0,0 +1,97 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+)
+
+// CBORTagUUID is the tag of binary UUIDs in the IANA CBOR tags registry.
+const CBORTagUUID = 37
+
+// CBOR major types and simple values, see RFC 8949 section 3.
+const (
+	cborBytes = 2
+	cborText  = 3
+	cborTag   = 6
+	cborNull  = 0xF6
+)
+
+// MarshalCBOR returns uuid as a CBOR byte string with tag 37, implementing
+// the Marshaler interface of CBOR libraries such as fxamacker/cbor. A nil UUID
+// is written as CBOR null.
+func (uuid UUID) MarshalCBOR() ([]byte, error) {
+	if uuid == nil {
+		return []byte{cborNull}, nil
+	}
+	if len(uuid) != 16 {
+		return nil, fmt.Errorf("uuid: invalid UUID length %d", len(uuid))
+	}
+
+	data := appendCBORHead(make([]byte, 0, 19), cborTag, CBORTagUUID)
+	data = appendCBORHead(data, cborBytes, 16)
+
+	return append(data, uuid...), nil
+}
+
+// UnmarshalCBOR decodes a 16 byte CBOR byte string, with or without tag 37,
+// a text string in any of the forms accepted by Parse, or null, which
+// decodes to a nil UUID, implementing the Unmarshaler interface of CBOR
+// libraries such as fxamacker/cbor.
+func (uuid *UUID) UnmarshalCBOR(data []byte) error {
+	if len(data) == 1 && data[0] == cborNull {
+		*uuid = nil
+		return nil
+	}
+
+	major, arg, rest, ok := readCBORHead(data)
+	if ok && major == cborTag {
+		if arg != CBORTagUUID {
+			return fmt.Errorf("uuid: invalid CBOR tag %d", arg)
+		}
+		major, arg, rest, ok = readCBORHead(rest)
+	}
+	if !ok || arg != uint64(len(rest)) {
+		return fmt.Errorf("uuid: invalid CBOR UUID")
+	}
+
+	switch major {
+	case cborBytes:
+		return uuid.UnmarshalBinary(rest)
+	case cborText:
+		return uuid.UnmarshalText(rest)
+	}
+
+	return fmt.Errorf("uuid: cannot decode CBOR major type %d into UUID", major)
+}
+
+// appendCBORHead appends the head of a CBOR data item with an argument
+// below 256.
+func appendCBORHead(dst []byte, major byte, arg byte) []byte {
+	if arg < 24 {
+		return append(dst, major<<5|arg)
+	}
+
+	return append(dst, major<<5|24, arg)
+}
+
+// readCBORHead reads the head of a CBOR data item with an argument below 256,
+// the only ones that occur in encoded UUIDs, and returns the major type, the
+// argument and the rest of data.
+func readCBORHead(data []byte) (byte, uint64, []byte, bool) {
+	if len(data) == 0 {
+		return 0, 0, nil, false
+	}
+
+	major, info := data[0]>>5, data[0]&0x1F
+	switch {
+	case info < 24:
+		return major, uint64(info), data[1:], true
+	case info == 24 && len(data) >= 2:
+		return major, uint64(data[1]), data[2:], true
+	}
+
+	return 0, 0, nil, false
+}
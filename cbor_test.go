@@ -0,0 +1,50 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarshalCBOR(t *testing.T) {
+	data, err := NamespaceDNS.MarshalCBOR()
+	expected := append([]byte{0xD8, 0x25, 0x50}, NamespaceDNS...)
+	if err != nil || !bytes.Equal(data, expected) {
+		t.Errorf("Failed to marshal CBOR. Expected: %x, Received: %x",
+			expected, data)
+	}
+	if data, _ := UUID(nil).MarshalCBOR(); !bytes.Equal(data, []byte{0xF6}) {
+		t.Errorf("Failed to marshal nil UUID as null. Received: %x", data)
+	}
+
+	text := NamespaceDNS.String()
+	for _, input := range [][]byte{
+		expected,
+		expected[2:],
+		append([]byte{0x78, byte(len(text))}, text...),
+		append([]byte{0xD8, 0x25, 0x78, byte(len(text))}, text...),
+	} {
+		var result UUID
+		if err := result.UnmarshalCBOR(input); err != nil ||
+			!result.Equal(NamespaceDNS) {
+			t.Errorf("Failed to unmarshal CBOR %x. Expected: %s, Received: %s",
+				input, NamespaceDNS, result)
+		}
+	}
+
+	result := NewV4()
+	if err := result.UnmarshalCBOR([]byte{0xF6}); err != nil || result != nil {
+		t.Errorf("Failed to unmarshal CBOR null. Received: %s", result)
+	}
+
+	for _, input := range [][]byte{
+		nil,
+		expected[:len(expected)-1],
+		append([]byte{0xD8, 0x26, 0x50}, NamespaceDNS...),
+		append([]byte{0x4F}, NamespaceDNS[:15]...),
+		{0x01},
+	} {
+		if err := result.UnmarshalCBOR(input); err == nil {
+			t.Errorf("unmarshaled invalid CBOR %x", input)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"time"
+)
+
+// Clock is the source of time of a Generator. Replacing it lets tests freeze
+// time and lets alternative clocks, such as hybrid logical clocks or
+// NTP-disciplined sources, drive the time-based versions.
+type Clock interface {
+	// Now returns the current time, used by Version 7, COMB and Squuid.
+	Now() time.Time
+	// Ticks returns the current time as 100s of nanoseconds since the
+	// Gregorian epoch, used by Versions 1, 2 and 6.
+	Ticks() uint64
+}
+
+// ClockFunc adapts a function returning the current time into a Clock.
+type ClockFunc func() time.Time
+
+// Now returns f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// Ticks returns f() as 100s of nanoseconds since the Gregorian epoch.
+func (f ClockFunc) Ticks() uint64 {
+	return nanos100sAt(f())
+}
+
+// WallClock reads the wall clock each time, so its readings follow NTP steps
+// and manual changes of the system time, backwards included.
+var WallClock Clock = ClockFunc(time.Now)
+
+// systemClock is the Clock used unless WithClock is given.
+var systemClock = MonotonicClock()
+
+// MonotonicClock returns a Clock that reads the wall clock once and then
+// advances it by the monotonic clock, so NTP steps cannot make time-based
+// UUIDs go out of order or repeat. Its readings drift from the wall clock by
+// any step applied after it was created, and on some platforms by the time
+// the machine was suspended. It is the default Clock of every Generator.
+func MonotonicClock() Clock {
+	base := time.Now()
+
+	return ClockFunc(func() time.Time {
+		// time.Since uses the monotonic reading of base
+		return base.Add(time.Since(base)).Round(0)
+	})
+}
+
+// FrozenClock returns a Clock that always reports t, for tests that assert
+// the timestamp embedded in generated UUIDs.
+func FrozenClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time {
+		return t
+	})
+}
@@ -0,0 +1,74 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+// tickClock is a Clock reporting a settable count of 100ns ticks.
+type tickClock struct {
+	ticks uint64
+}
+
+func (c *tickClock) Now() time.Time {
+	return time.Unix(0, int64(c.ticks-epochDiffNanos100s)*100)
+}
+
+func (c *tickClock) Ticks() uint64 {
+	return c.ticks
+}
+
+// v1Ticks extracts the 60-bit timestamp of a Version 1 UUID.
+func v1Ticks(uuid UUID) uint64 {
+	return uint64(uuid[0])<<24 | uint64(uuid[1])<<16 | uint64(uuid[2])<<8 |
+		uint64(uuid[3]) | uint64(uuid[4])<<40 | uint64(uuid[5])<<32 |
+		uint64(uuid[6]&0x0F)<<56 | uint64(uuid[7])<<48
+}
+
+func TestClock(t *testing.T) {
+	clock := &tickClock{ticks: nanos100sAt(seededEpoch)}
+	g := NewGenerator(WithClock(clock))
+
+	clock.ticks += 1000
+	if ticks := v1Ticks(g.NewV1()); ticks != clock.ticks {
+		t.Errorf("Failed to use clock ticks. Expected: %d, Received: %d",
+			clock.ticks, ticks)
+	}
+
+	// a frozen clock still yields unique UUIDs by counting within the tick
+	if a, b := g.NewV1(), g.NewV1(); a.Equal(b) {
+		t.Errorf("frozen clock produced duplicate UUIDs")
+	}
+}
+
+func TestFrozenClock(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	clock := FrozenClock(now)
+
+	if !clock.Now().Equal(now) || clock.Now() != clock.Now() {
+		t.Errorf("Failed to freeze clock. Expected: %s, Received: %s", now,
+			clock.Now())
+	}
+	if clock.Ticks() != nanos100sAt(now) {
+		t.Errorf("Failed to convert ticks. Expected: %d, Received: %d",
+			nanos100sAt(now), clock.Ticks())
+	}
+}
+
+func TestMonotonicClock(t *testing.T) {
+	clock := MonotonicClock()
+
+	last := clock.Ticks()
+	for i := 0; i < 1000; i++ {
+		ticks := clock.Ticks()
+		if ticks < last {
+			t.Fatalf("monotonic clock went backwards: %d < %d", ticks, last)
+		}
+		last = ticks
+	}
+
+	// without steps the reading matches the wall clock
+	if drift := time.Since(clock.Now()); drift < -time.Second || drift > time.Second {
+		t.Errorf("monotonic clock drifted from the wall clock by %s", drift)
+	}
+}
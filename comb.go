@@ -0,0 +1,47 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// NewCOMB generates a COMB (combined GUID/timestamp) UUID for use as a
+// clustered SQL Server uniqueidentifier key. SQL Server orders
+// uniqueidentifier values by their last six bytes first, so the 48-bit
+// milliseconds since the Unix epoch are stored there and the remaining bits
+// are those of a Version 4 UUID. Returns 128-bit / 16 byte array
+// representing the UUID.
+func NewCOMB() UUID {
+	return Default().NewCOMB()
+}
+
+// NewCOMB generates a COMB UUID using the clock and random source of g.
+func (g *Generator) NewCOMB() UUID {
+	result := g.NewV4()
+	millis := uint64(g.clockSource().Now().UnixMilli())
+	result[10] = byte(millis >> 40)
+	result[11] = byte(millis >> 32)
+	result[12] = byte(millis >> 24)
+	result[13] = byte(millis >> 16)
+	result[14] = byte(millis >> 8)
+	result[15] = byte(millis)
+
+	return result
+}
+
+// NewSquuid generates a sequential UUID (squuid) for stores that cannot
+// adopt Version 7. The first 32 bits of a Version 4 UUID are replaced with
+// the seconds since the Unix epoch, giving coarse time locality while the
+// version and variant remain those of Version 4. Returns 128-bit / 16 byte
+// array representing the UUID.
+func NewSquuid() UUID {
+	return Default().NewSquuid()
+}
+
+// NewSquuid generates a sequential UUID using the clock and random source of
+// g.
+func (g *Generator) NewSquuid() UUID {
+	result := g.NewV4()
+	copy(result[0:4], uint32ToBytes(uint32(g.clockSource().Now().Unix())))
+
+	return result
+}
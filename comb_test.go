@@ -0,0 +1,41 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCOMB(t *testing.T) {
+	before := uint64(time.Now().UnixMilli())
+	result := NewCOMB()
+	after := uint64(time.Now().UnixMilli())
+
+	if result.Version() != Version4 || result.Variant() != VariantRFC4122 {
+		t.Fatalf("incorrect version or variant detected")
+	}
+
+	millis := uint64(result[10])<<40 | uint64(result[11])<<32 |
+		uint64(result[12])<<24 | uint64(result[13])<<16 |
+		uint64(result[14])<<8 | uint64(result[15])
+	if millis < before || millis > after {
+		t.Errorf("incorrect timestamp detected. Expected: %d-%d, "+
+			"Received: %d", before, after, millis)
+	}
+}
+
+func TestNewSquuid(t *testing.T) {
+	before := uint32(time.Now().Unix())
+	result := NewSquuid()
+	after := uint32(time.Now().Unix())
+
+	if result.Version() != Version4 || result.Variant() != VariantRFC4122 {
+		t.Fatalf("incorrect version or variant detected")
+	}
+
+	seconds := uint32(result[0])<<24 | uint32(result[1])<<16 |
+		uint32(result[2])<<8 | uint32(result[3])
+	if seconds < before || seconds > after {
+		t.Errorf("incorrect timestamp detected. Expected: %d-%d, "+
+			"Received: %d", before, after, seconds)
+	}
+}
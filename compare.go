@@ -0,0 +1,39 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"slices"
+)
+
+// Compare returns an integer comparing two UUIDs in lexicographic byte order.
+// The result will be 0 if a == b, -1 if a < b, and +1 if a > b. Compare has
+// the signature expected by slices.SortFunc and slices.BinarySearchFunc.
+func Compare(a, b UUID) int {
+	return bytes.Compare(a, b)
+}
+
+// Equal reports whether uuid and other contain the same bytes.
+func (uuid UUID) Equal(other UUID) bool {
+	return bytes.Equal(uuid, other)
+}
+
+// Less reports whether uuid sorts before other in lexicographic byte order.
+func (uuid UUID) Less(other UUID) bool {
+	return bytes.Compare(uuid, other) < 0
+}
+
+// Sort sorts list in ascending lexicographic byte order.
+func Sort(list []UUID) {
+	slices.SortFunc(list, Compare)
+}
+
+// SearchSorted searches for target in list, which must be sorted in ascending
+// order as by Sort. Returns the position where target is found, or the
+// position where it would be inserted, and whether it was found.
+func SearchSorted(list []UUID, target UUID) (int, bool) {
+	return slices.BinarySearchFunc(list, target, Compare)
+}
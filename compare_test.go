@@ -0,0 +1,61 @@
+package uuid
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	low, _ := Parse("00000000-0000-0000-0000-000000000001")
+	high, _ := Parse("01000000-0000-0000-0000-000000000000")
+
+	if Compare(low, high) != -1 || Compare(high, low) != 1 ||
+		Compare(low, low) != 0 {
+		t.Errorf("incorrect comparison of %s and %s", low, high)
+	}
+
+	if !low.Less(high) || high.Less(low) || low.Less(low) {
+		t.Errorf("incorrect ordering of %s and %s", low, high)
+	}
+
+	if !low.Equal(UUID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}) ||
+		low.Equal(high) {
+		t.Errorf("incorrect equality of %s and %s", low, high)
+	}
+
+	// the methods should plug straight into the sort package
+	list := []UUID{Max, high, Nil, low}
+	sort.Slice(list, func(i, j int) bool { return list[i].Less(list[j]) })
+	if !list[0].IsNil() || !list[1].Equal(low) || !list[2].Equal(high) ||
+		!list[3].IsMax() {
+		t.Errorf("incorrect sort order: %v", list)
+	}
+}
+
+func TestSort(t *testing.T) {
+	list := make([]UUID, 100)
+	for i := range list {
+		list[i] = NewV4()
+	}
+
+	Sort(list)
+	for i := 1; i < len(list); i++ {
+		if Compare(list[i-1], list[i]) > 0 {
+			t.Fatalf("list not sorted at %d: %s > %s", i, list[i-1], list[i])
+		}
+	}
+
+	for i, target := range list {
+		position, found := SearchSorted(list, target)
+		if !found || position != i {
+			t.Errorf("Failed to find %s. Expected: %d, Received: %d",
+				target, i, position)
+		}
+	}
+
+	position, found := SearchSorted(list, Max)
+	if found || position != len(list) {
+		t.Errorf("incorrect search result for missing UUID: %d, %v",
+			position, found)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+)
+
+// v1CounterLimit is the number of 100ns intervals the timestamps of Version
+// 1, 2 and 6 UUIDs may run ahead of the clock. Within a system time interval
+// the timestamp is simulated by counting up from the time read, and RFC 4122
+// section 4.2.1.2 limits the count to the number of 100ns intervals per
+// system time interval; 1ms covers the coarsest clocks Go runs on. Counting
+// any further would borrow timestamps that belong to later intervals.
+const v1CounterLimit = 10000
+
+// ErrCounterExhausted is returned by the time-based constructors under
+// CounterReturnError when more UUIDs are requested within a system time
+// interval than the timestamp can count.
+var ErrCounterExhausted = errors.New("uuid: timestamp counter exhausted")
+
+// CounterPolicy selects how Version 1, 2 and 6 generation behaves when the
+// timestamp counter of the current system time interval is exhausted.
+type CounterPolicy int32
+
+const (
+	// CounterWait waits for the clock to advance. This is the default. A
+	// Clock that never advances, such as FrozenClock, blocks forever.
+	CounterWait CounterPolicy = iota
+	// CounterReturnError returns ErrCounterExhausted from the constructors
+	// that return errors, such as NewV1E, and panics in the ones that do
+	// not, such as NewV1.
+	CounterReturnError
+)
+
+// SetCounterPolicy sets how Version 1, 2 and 6 generation behaves when the
+// timestamp counter is exhausted.
+func SetCounterPolicy(policy CounterPolicy) {
+	Default().SetCounterPolicy(policy)
+}
+
+// SetCounterPolicy sets how g behaves when its timestamp counter is
+// exhausted.
+func (g *Generator) SetCounterPolicy(policy CounterPolicy) {
+	g.counterPolicy.Store(int32(policy))
+}
@@ -0,0 +1,80 @@
+package uuid
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// atomicClock is a Clock reporting a count of 100ns ticks that can be moved
+// from another goroutine.
+type atomicClock struct {
+	ticks atomic.Uint64
+}
+
+func (c *atomicClock) Now() time.Time {
+	return time.Unix(0, int64(c.Ticks()-epochDiffNanos100s)*100)
+}
+
+func (c *atomicClock) Ticks() uint64 {
+	return c.ticks.Load()
+}
+
+func TestCounterReturnError(t *testing.T) {
+	clock := &tickClock{ticks: nanos100sAt(seededEpoch)}
+	g := NewGenerator(WithClock(clock))
+	g.SetCounterPolicy(CounterReturnError)
+
+	if _, err := g.AppendBatch(nil, Version1, v1CounterLimit); err != nil {
+		t.Fatalf("failed to generate batch: %v", err)
+	}
+	if _, err := g.NewV1E(); err != nil {
+		t.Fatalf("failed to generate the last counted UUID: %v", err)
+	}
+	if _, err := g.NewV6E(); !errors.Is(err, ErrCounterExhausted) {
+		t.Errorf("Failed to detect exhausted counter. Expected: %v, "+
+			"Received: %v", ErrCounterExhausted, err)
+	}
+	if _, err := g.NewV2E(DomainPerson, 1000); !errors.Is(err,
+		ErrCounterExhausted) {
+		t.Errorf("Failed to detect exhausted counter. Expected: %v, "+
+			"Received: %v", ErrCounterExhausted, err)
+	}
+	if _, err := g.New(Version2, WithDomain(DomainPerson, 1000)); !errors.Is(
+		err, ErrCounterExhausted) {
+		t.Errorf("Failed to detect exhausted counter. Expected: %v, "+
+			"Received: %v", ErrCounterExhausted, err)
+	}
+
+	clock.ticks++
+	if _, err := g.NewV1E(); err != nil {
+		t.Errorf("failed to generate after the clock advanced: %v", err)
+	}
+}
+
+func TestCounterWait(t *testing.T) {
+	clock := &atomicClock{}
+	clock.ticks.Store(nanos100sAt(seededEpoch))
+	g := NewGenerator(WithClock(clock))
+
+	g.AppendBatch(nil, Version1, v1CounterLimit+1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		clock.ticks.Add(1)
+	}()
+
+	// blocks until the clock advances
+	result := g.NewV1()
+	if ticks := v1Ticks(result); ticks != clock.Ticks()+v1CounterLimit {
+		t.Errorf("incorrect timestamp after waiting. Expected: %d, "+
+			"Received: %d", clock.Ticks()+v1CounterLimit, ticks)
+	}
+	if stalls := g.Stats().CounterStalls; stalls != 1 {
+		t.Errorf("Failed to count stalls. Expected: 1, Received: %d", stalls)
+	}
+
+	if _, err := g.AppendBatch(nil, Version1, v1CounterLimit+2); err == nil {
+		t.Errorf("generated a batch larger than the counter")
+	}
+}
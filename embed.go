@@ -0,0 +1,149 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// TwitterEpoch is the epoch of Twitter Snowflake IDs, for FromSnowflake and
+// ToSnowflake.
+var TwitterEpoch = time.UnixMilli(1288834974657)
+
+// FromKSUID embeds the 20 bytes of a KSUID, its 32-bit timestamp followed by
+// its 128-bit payload, in a Version 8 UUID. A UUID has only 122 bits to
+// spare, so the timestamp and the first 90 bits of the payload are kept and
+// the last 38 bits of the payload are lost. The UUIDs sort in the order of
+// their KSUIDs, except for KSUIDs that differ only in the lost bits.
+func FromKSUID(ksuid [20]byte) UUID {
+	return packV8(ksuid[:])
+}
+
+// ToKSUID extracts the KSUID embedded by FromKSUID. The lost bits of the
+// payload are zero. Returns an error if uuid is not a Version 8 UUID.
+func (uuid UUID) ToKSUID() ([20]byte, error) {
+	var ksuid [20]byte
+	if err := unpackV8(ksuid[:], uuid); err != nil {
+		return ksuid, err
+	}
+
+	return ksuid, nil
+}
+
+// FromXID embeds the 12 bytes of an xid, its 32-bit timestamp, machine ID,
+// process ID and counter, in a Version 8 UUID. The conversion is lossless and
+// the UUIDs sort in the order of their xids, so existing xid keys can move to
+// UUID columns without reordering.
+func FromXID(xid [12]byte) UUID {
+	return packV8(xid[:])
+}
+
+// ToXID extracts the xid embedded by FromXID. Returns an error if uuid is not
+// a Version 8 UUID.
+func (uuid UUID) ToXID() ([12]byte, error) {
+	var xid [12]byte
+	if err := unpackV8(xid[:], uuid); err != nil {
+		return xid, err
+	}
+
+	return xid, nil
+}
+
+// FromObjectID embeds the 12 bytes of a MongoDB ObjectID, its 32-bit
+// timestamp, random value and counter, in a Version 8 UUID. The conversion is
+// lossless and the UUIDs sort in the order of their ObjectIDs, so
+// Mongo-keyed records keep their order when they move to UUID-keyed tables.
+func FromObjectID(id [12]byte) UUID {
+	return packV8(id[:])
+}
+
+// ToObjectID extracts the ObjectID embedded by FromObjectID. Returns an error
+// if uuid is not a Version 8 UUID.
+func (uuid UUID) ToObjectID() ([12]byte, error) {
+	var id [12]byte
+	if err := unpackV8(id[:], uuid); err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
+
+// FromSnowflake embeds a 64-bit Snowflake ID, a 41-bit millisecond timestamp
+// since epoch followed by 22 bits of worker ID and sequence, in a Version 8
+// UUID. The timestamp is rebased to the Unix epoch and stored in the first 48
+// bits, as in Version 7 UUIDs, so the UUIDs sort in the order of their IDs
+// and in time order with Version 7 UUIDs. Returns an error if id is negative.
+func FromSnowflake(id int64, epoch time.Time) (UUID, error) {
+	if id < 0 {
+		return nil, fmt.Errorf("uuid: invalid Snowflake ID %d", id)
+	}
+
+	millis := uint64(epoch.UnixMilli()) + uint64(id)>>22
+	var data [9]byte
+	binary.BigEndian.PutUint64(data[:], millis<<16|uint64(id)>>6&0xFFFF)
+	data[8] = byte(id << 2)
+
+	return packV8(data[:]), nil
+}
+
+// ToSnowflake extracts the Snowflake ID embedded by FromSnowflake with the
+// same epoch. Returns an error if uuid is not a Version 8 UUID or its
+// timestamp does not fit a Snowflake ID of epoch.
+func (uuid UUID) ToSnowflake(epoch time.Time) (int64, error) {
+	var data [9]byte
+	if err := unpackV8(data[:], uuid); err != nil {
+		return 0, err
+	}
+
+	value := binary.BigEndian.Uint64(data[:])
+	millis, base := value>>16, uint64(epoch.UnixMilli())
+	if millis < base || millis-base >= 1<<41 {
+		return 0, fmt.Errorf("uuid: %s does not hold a Snowflake ID", uuid)
+	}
+
+	id := (millis-base)<<22 | (value&0xFFFF)<<6 | uint64(data[8])>>2
+	return int64(id), nil
+}
+
+// packV8 returns a Version 8 UUID whose 122 custom bits hold the first 122
+// bits of data, most significant first, and zeros if data is shorter. The
+// bits skip the version and variant fields, so the UUIDs sort in the order of
+// the data they embed.
+func packV8(data []byte) UUID {
+	var b [16]byte
+	copy(b[:], data)
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+
+	// 48 bits before the version, 12 bits after it and 62 bits after the
+	// variant
+	uuid := make(UUID, 16)
+	binary.BigEndian.PutUint64(uuid[:8], hi&^0xFFFF|0x8000|hi>>4&0x0FFF)
+	binary.BigEndian.PutUint64(uuid[8:],
+		0x8000000000000000|(hi&0x0F)<<58|lo>>6)
+
+	return uuid
+}
+
+// unpackV8 writes the data embedded in uuid by packV8 to dst, filling the
+// bytes past the first 122 bits with zeros. Returns an error if uuid is not a
+// Version 8 UUID.
+func unpackV8(dst []byte, uuid UUID) error {
+	if len(uuid) != 16 || uuid.Version() != Version8 ||
+		uuid.Variant() != VariantRFC4122 {
+		return fmt.Errorf("uuid: %s is not a Version 8 UUID", uuid)
+	}
+
+	hi := binary.BigEndian.Uint64(uuid[:8])
+	lo := binary.BigEndian.Uint64(uuid[8:])
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], hi&^0xFFFF|hi<<4&0xFFF0|lo>>58&0x0F)
+	binary.BigEndian.PutUint64(b[8:], lo<<6)
+	clear(dst)
+	copy(dst, b[:])
+
+	return nil
+}
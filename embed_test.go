@@ -0,0 +1,158 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestPackV8(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		data := make([]byte, 16)
+		u.readRandom(data)
+		data[15] &^= 0x3F // only 122 bits fit
+
+		uuid := packV8(data)
+		if uuid.Version() != Version8 || uuid.Variant() != VariantRFC4122 {
+			t.Fatalf("packed data into an invalid version 8 UUID: %s", uuid)
+		}
+		result := make([]byte, 16)
+		err := unpackV8(result, uuid)
+		if err != nil || !bytes.Equal(result, data) {
+			t.Fatalf("Failed to unpack data. Expected: %x, Received: %x",
+				data, result)
+		}
+	}
+
+	// the UUIDs sort in the order of the data
+	list := [][]byte{{0x00, 0x01}, {0xFF}, {0x7F, 0xFF, 0xFF}, {0x80},
+		{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01}}
+	for i, a := range list {
+		for _, b := range list[i+1:] {
+			if bytes.Compare(a, b) != Compare(packV8(a), packV8(b)) {
+				t.Errorf("packed UUIDs of %x and %x are not in data order",
+					a, b)
+			}
+		}
+	}
+
+	if err := unpackV8(make([]byte, 16), NewV4()); err == nil {
+		t.Errorf("unpacked a version 4 UUID")
+	}
+}
+
+func TestKSUID(t *testing.T) {
+	// the KSUID 0ujtsYcgvSTl8PAuAdqWYSMnLOv, with timestamp 107608047
+	ksuid := [20]byte{0x06, 0x69, 0xF7, 0xEF, 0xB5, 0xA1, 0xCD, 0x34,
+		0xB5, 0xF9, 0x9D, 0x11, 0x54, 0xFB, 0x68, 0x53, 0x34, 0x5C, 0x97, 0x35}
+	uuid := FromKSUID(ksuid)
+	if uuid.Version() != Version8 || !bytes.Equal(uuid[:4], ksuid[:4]) {
+		t.Errorf("Failed to embed KSUID timestamp. Expected: %x, Received: %s",
+			ksuid[:4], uuid)
+	}
+
+	result, err := uuid.ToKSUID()
+	expected := ksuid
+	expected[15] &^= 0x3F
+	clear(expected[16:])
+	if err != nil || result != expected {
+		t.Errorf("Failed to extract KSUID. Expected: %x, Received: %x",
+			expected, result)
+	}
+
+	later := ksuid
+	later[3]++
+	if Compare(uuid, FromKSUID(later)) != -1 {
+		t.Errorf("embedded KSUIDs are not in timestamp order")
+	}
+	if _, err := NewV7().ToKSUID(); err == nil {
+		t.Errorf("extracted a KSUID from a version 7 UUID")
+	}
+}
+
+func TestXID(t *testing.T) {
+	// the xid 9m4e2mr0ui3e8a215n4g
+	xid := [12]byte{0x4D, 0x88, 0xE1, 0x5B, 0x60, 0xF4, 0x86, 0xE4, 0x28,
+		0x41, 0x2D, 0xC9}
+	uuid := FromXID(xid)
+	if uuid.Version() != Version8 || !bytes.Equal(uuid[:4], xid[:4]) {
+		t.Errorf("Failed to embed xid timestamp. Expected: %x, Received: %s",
+			xid[:4], uuid)
+	}
+	if result, err := uuid.ToXID(); err != nil || result != xid {
+		t.Errorf("Failed to extract xid. Expected: %x, Received: %x", xid,
+			result)
+	}
+
+	later := xid
+	later[11]++
+	if Compare(uuid, FromXID(later)) != -1 {
+		t.Errorf("embedded xids are not in counter order")
+	}
+	if _, err := NewV4().ToXID(); err == nil {
+		t.Errorf("extracted an xid from a version 4 UUID")
+	}
+}
+
+func TestSnowflake(t *testing.T) {
+	// a Snowflake ID of 2022 with all worker and sequence bits set
+	const id = 1496114359181897728 | 0x3FFFFF
+	at := time.UnixMilli(TwitterEpoch.UnixMilli() + id>>22)
+
+	uuid, err := FromSnowflake(id, TwitterEpoch)
+	if err != nil || uuid.Version() != Version8 {
+		t.Fatalf("Failed to embed Snowflake ID: %s, %v", uuid, err)
+	}
+	// the first 48 bits hold the Unix time in milliseconds, as in version 7
+	millis := uint64(uuid[0])<<40 | uint64(uuid[1])<<32 | uint64(uuid[2])<<24 |
+		uint64(uuid[3])<<16 | uint64(uuid[4])<<8 | uint64(uuid[5])
+	if millis != uint64(at.UnixMilli()) {
+		t.Errorf("Failed to align Snowflake timestamp. Expected: %d, "+
+			"Received: %d", at.UnixMilli(), millis)
+	}
+	result, err := uuid.ToSnowflake(TwitterEpoch)
+	if err != nil || result != id {
+		t.Errorf("Failed to extract Snowflake ID. Expected: %d, Received: %d",
+			int64(id), result)
+	}
+
+	next, _ := FromSnowflake(id+1, TwitterEpoch)
+	if Compare(uuid, next) != -1 ||
+		Compare(NewV7At(at.Add(-time.Second)), uuid) != -1 {
+		t.Errorf("embedded Snowflake IDs are not in time order")
+	}
+
+	if _, err := FromSnowflake(-1, TwitterEpoch); err == nil {
+		t.Errorf("embedded a negative Snowflake ID")
+	}
+	if _, err := uuid.ToSnowflake(time.Now()); err == nil {
+		t.Errorf("extracted a Snowflake ID from before its epoch")
+	}
+	if _, err := NewV7().ToSnowflake(TwitterEpoch); err == nil {
+		t.Errorf("extracted a Snowflake ID from a version 7 UUID")
+	}
+}
+
+func TestObjectID(t *testing.T) {
+	// the ObjectID 507f1f77bcf86cd799439011
+	id := [12]byte{0x50, 0x7F, 0x1F, 0x77, 0xBC, 0xF8, 0x6C, 0xD7, 0x99,
+		0x43, 0x90, 0x11}
+	uuid := FromObjectID(id)
+	if uuid.Version() != Version8 || !bytes.Equal(uuid[:4], id[:4]) {
+		t.Errorf("Failed to embed ObjectID timestamp. Expected: %x, "+
+			"Received: %s", id[:4], uuid)
+	}
+	if result, err := uuid.ToObjectID(); err != nil || result != id {
+		t.Errorf("Failed to extract ObjectID. Expected: %x, Received: %x", id,
+			result)
+	}
+
+	earlier := id
+	earlier[3]--
+	if Compare(FromObjectID(earlier), uuid) != -1 {
+		t.Errorf("embedded ObjectIDs are not in timestamp order")
+	}
+	if _, err := NewV4().ToObjectID(); err == nil {
+		t.Errorf("extracted an ObjectID from a version 4 UUID")
+	}
+}
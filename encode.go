@@ -0,0 +1,286 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// ShortAlphabet is the base57 alphabet of the shortuuid libraries for Python
+// and JavaScript, which leaves out the similar looking 0, 1, I, O and l.
+const ShortAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base62Alphabet is the alphabet of EncodeBase62, digits then uppercase then
+// lowercase letters, so encoded UUIDs of equal length sort in byte order.
+const Base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// EncodeBase64 returns the 22 character unpadded url-safe base64 form of
+// uuid (RFC 4648 section 5), for compact tokens in URLs and headers. Panics if
+// uuid is not 16 bytes long.
+func (uuid UUID) EncodeBase64() string {
+	array := uuid.Array()
+	return base64.RawURLEncoding.EncodeToString(array[:])
+}
+
+// DecodeBase64 converts the 22 character unpadded url-safe base64 form
+// returned by EncodeBase64 into a UUID.
+func DecodeBase64(s string) (UUID, error) {
+	if len(s) != 22 {
+		return nil, fmt.Errorf("uuid: invalid base64 UUID %q", s)
+	}
+
+	uuid := make(UUID, 16)
+	_, err := base64.RawURLEncoding.Strict().Decode(uuid, []byte(s))
+	if err != nil {
+		return nil, fmt.Errorf("uuid: invalid base64 UUID %q", s)
+	}
+
+	return uuid, nil
+}
+
+// EncodeShort returns the 22 character base57 form of uuid in ShortAlphabet,
+// compatible with the encode function of the shortuuid libraries. Panics if
+// uuid is not 16 bytes long.
+func (uuid UUID) EncodeShort() string {
+	return encodeBase(uuid.Array(), ShortAlphabet, 22)
+}
+
+// DecodeShort converts the base57 form returned by EncodeShort, or by the
+// shortuuid libraries, into a UUID.
+func DecodeShort(s string) (UUID, error) {
+	return decodeBase(s, ShortAlphabet, 22)
+}
+
+// EncodeBase62 returns the 22 character base62 form of uuid in Base62Alphabet,
+// for identifiers that must be purely alphanumeric. Panics if uuid is not 16
+// bytes long.
+func (uuid UUID) EncodeBase62() string {
+	return encodeBase(uuid.Array(), Base62Alphabet, 22)
+}
+
+// DecodeBase62 converts the base62 form returned by EncodeBase62 into a UUID.
+func DecodeBase62(s string) (UUID, error) {
+	return decodeBase(s, Base62Alphabet, 22)
+}
+
+// Proquint consonants and vowels, each word encoding 16 bits as consonant,
+// vowel, consonant, vowel, consonant.
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+// EncodeProquint returns uuid as eight pronounceable five letter words
+// separated by dashes, e.g. lusab-babad-..., following the proquint
+// specification, for identifiers that are read out loud. Panics if uuid is
+// not 16 bytes long.
+func (uuid UUID) EncodeProquint() string {
+	array := uuid.Array()
+
+	words := make([]byte, 0, 47)
+	for i := 0; i < 16; i += 2 {
+		if i > 0 {
+			words = append(words, '-')
+		}
+		word := binary.BigEndian.Uint16(array[i:])
+		words = append(words, proquintConsonants[word>>12],
+			proquintVowels[word>>10&0x03], proquintConsonants[word>>6&0x0F],
+			proquintVowels[word>>4&0x03], proquintConsonants[word&0x0F])
+	}
+
+	return string(words)
+}
+
+// DecodeProquint converts the words returned by EncodeProquint into a UUID.
+// Letters may be in upper or lower case.
+func DecodeProquint(s string) (UUID, error) {
+	words := strings.Split(strings.ToLower(s), "-")
+	if len(words) != 8 {
+		return nil, fmt.Errorf("uuid: invalid proquint UUID %q", s)
+	}
+
+	uuid := make(UUID, 16)
+	for i, word := range words {
+		if len(word) != 5 {
+			return nil, fmt.Errorf("uuid: invalid proquint UUID %q", s)
+		}
+
+		var value uint16
+		for j := 0; j < 5; j++ {
+			letters, shift := proquintConsonants, 4
+			if j%2 == 1 {
+				letters, shift = proquintVowels, 2
+			}
+			digit := strings.IndexByte(letters, word[j])
+			if digit < 0 {
+				return nil, fmt.Errorf("uuid: invalid proquint UUID %q", s)
+			}
+			value = value<<shift | uint16(digit)
+		}
+		binary.BigEndian.PutUint16(uuid[i*2:], value)
+	}
+
+	return uuid, nil
+}
+
+// crockfordAlphabet is the lowercase Crockford base32 alphabet of TypeIDs and
+// ULIDs, which leaves out i, l, o and u.
+const crockfordAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// TypeID returns the TypeID of uuid with the given type prefix, e.g.
+// user_01h455vb4pex5vsknk084sn02q: the prefix, an underscore and the 26
+// character lowercase Crockford base32 form of uuid. The prefix must be at
+// most 63 lowercase ASCII letters and underscores, not starting or ending
+// with an underscore, and is left out with its separator if empty. TypeIDs
+// are meant to hold Version 7 UUIDs, which makes them sort by creation time,
+// but any UUID is encoded.
+func TypeID(prefix string, uuid UUID) (string, error) {
+	if !validTypeIDPrefix(prefix) {
+		return "", fmt.Errorf("uuid: invalid TypeID prefix %q", prefix)
+	}
+	if uuid != nil && len(uuid) != 16 {
+		return "", fmt.Errorf("uuid: invalid UUID length %d", len(uuid))
+	}
+
+	suffix := encodeCrockford(uuid.Array())
+	if prefix == "" {
+		return suffix, nil
+	}
+
+	return prefix + "_" + suffix, nil
+}
+
+// ParseTypeID splits a TypeID returned by TypeID into its prefix and UUID.
+func ParseTypeID(s string) (string, UUID, error) {
+	prefix, suffix := "", s
+	if i := strings.LastIndexByte(s, '_'); i >= 0 {
+		prefix, suffix = s[:i], s[i+1:]
+		if prefix == "" {
+			return "", nil, fmt.Errorf("uuid: invalid TypeID %q", s)
+		}
+	}
+	if !validTypeIDPrefix(prefix) {
+		return "", nil, fmt.Errorf("uuid: invalid TypeID prefix %q", prefix)
+	}
+
+	array, ok := decodeCrockford(suffix)
+	if !ok || strings.ToLower(suffix) != suffix {
+		return "", nil, fmt.Errorf("uuid: invalid TypeID %q", s)
+	}
+
+	return prefix, array[:], nil
+}
+
+// validTypeIDPrefix reports whether prefix is a valid TypeID prefix.
+func validTypeIDPrefix(prefix string) bool {
+	if len(prefix) > 63 || strings.HasPrefix(prefix, "_") ||
+		strings.HasSuffix(prefix, "_") {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; (c < 'a' || c > 'z') && c != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// encodeCrockford returns the 26 lowercase Crockford base32 digits of the
+// 128-bit big-endian value of b, the first digit holding the top 3 bits.
+func encodeCrockford(b [16]byte) string {
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+
+	var digits [26]byte
+	for i := 25; i >= 0; i-- {
+		digits[i] = crockfordAlphabet[lo&0x1F]
+		hi, lo = hi>>5, lo>>5|hi<<59
+	}
+
+	return string(digits[:])
+}
+
+// decodeCrockford converts 26 Crockford base32 digits in upper or lower case,
+// as returned by encodeCrockford, into the 16 bytes they encode. Reports
+// false if s is malformed or its value exceeds 128 bits.
+func decodeCrockford(s string) ([16]byte, bool) {
+	var b [16]byte
+	if len(s) != 26 || s[0] > '7' {
+		return b, false
+	}
+
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		digit := strings.IndexByte(crockfordAlphabet, c)
+		if digit < 0 {
+			return b, false
+		}
+		hi, lo = hi<<5|lo>>59, lo<<5|uint64(digit)
+	}
+	binary.BigEndian.PutUint64(b[:8], hi)
+	binary.BigEndian.PutUint64(b[8:], lo)
+
+	return b, true
+}
+
+// encodeBase returns the 128-bit big-endian value of b in the positional
+// numeral system of alphabet, most significant digit first, padded to width
+// digits with the first digit of alphabet.
+func encodeBase(b [16]byte, alphabet string, width int) string {
+	base := uint64(len(alphabet))
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+
+	digits := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, base)
+		lo, rem = bits.Div64(rem, lo, base)
+		digits[i] = alphabet[rem]
+	}
+
+	return string(digits)
+}
+
+// decodeBase converts s, width digits of alphabet as returned by encodeBase,
+// into a UUID. Returns an error if s has another length, a digit not in
+// alphabet or a value beyond 128 bits.
+func decodeBase(s string, alphabet string, width int) (UUID, error) {
+	if len(s) != width {
+		return nil, fmt.Errorf("uuid: invalid encoded UUID %q", s)
+	}
+
+	base := uint64(len(alphabet))
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(alphabet, s[i])
+		if digit < 0 {
+			return nil, fmt.Errorf("uuid: invalid encoded UUID %q", s)
+		}
+
+		// hi:lo = hi:lo*base + digit, failing on overflow
+		overflow, hiBase := bits.Mul64(hi, base)
+		carryOut, loBase := bits.Mul64(lo, base)
+		var carry uint64
+		lo, carry = bits.Add64(loBase, uint64(digit), 0)
+		hi, carry = bits.Add64(hiBase, carryOut, carry)
+		if overflow != 0 || carry != 0 {
+			return nil, fmt.Errorf("uuid: encoded UUID %q overflows 128 bits", s)
+		}
+	}
+
+	uuid := make(UUID, 16)
+	binary.BigEndian.PutUint64(uuid[:8], hi)
+	binary.BigEndian.PutUint64(uuid[8:], lo)
+
+	return uuid, nil
+}
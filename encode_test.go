@@ -0,0 +1,176 @@
+package uuid
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBase64(t *testing.T) {
+	if s := NamespaceDNS.EncodeBase64(); s != "a6e4EJ2tEdGAtADAT9QwyA" {
+		t.Errorf("Failed to encode base64. Expected: a6e4EJ2tEdGAtADAT9QwyA, "+
+			"Received: %s", s)
+	}
+
+	for i := 0; i < 100; i++ {
+		in := NewV4()
+		result, err := DecodeBase64(in.EncodeBase64())
+		if err != nil || !result.Equal(in) {
+			t.Fatalf("Failed to round trip base64. Expected: %s, Received: %s",
+				in, result)
+		}
+	}
+
+	for _, s := range []string{"", "a6e4EJ2tEdGAtADAT9Qwy",
+		"a6e4EJ2tEdGAtADAT9QwyA==", "a6e4EJ2tEdGAtADAT9Qwy+",
+		"a6e4EJ2tEdGAtADAT9QwyB"} {
+		if _, err := DecodeBase64(s); err == nil {
+			t.Errorf("decoded invalid base64 UUID %q", s)
+		}
+	}
+}
+
+func TestShort(t *testing.T) {
+	// the example of the shortuuid README
+	in, _ := Parse("3b1f8b40-222c-4a6e-b77e-779d5a94e21c")
+	if s := in.EncodeShort(); s != "CXc85b4rqinB7s5J52TRYb" {
+		t.Errorf("Failed to encode shortuuid. Expected: "+
+			"CXc85b4rqinB7s5J52TRYb, Received: %s", s)
+	}
+	if s := Nil.EncodeShort(); s != "2222222222222222222222" {
+		t.Errorf("Failed to pad shortuuid. Expected: 2222222222222222222222, "+
+			"Received: %s", s)
+	}
+	if s := Max.EncodeShort(); s != "oZEq7ovRbLq6UnGMPwc8B5" {
+		t.Errorf("Failed to encode max shortuuid. Expected: "+
+			"oZEq7ovRbLq6UnGMPwc8B5, Received: %s", s)
+	}
+
+	for i := 0; i < 100; i++ {
+		in := NewV4()
+		result, err := DecodeShort(in.EncodeShort())
+		if err != nil || !result.Equal(in) {
+			t.Fatalf("Failed to round trip shortuuid. Expected: %s, "+
+				"Received: %s", in, result)
+		}
+	}
+
+	for _, s := range []string{"", "CXc85b4rqinB7s5J52TRY",
+		"CXc85b4rqinB7s5J52TRY0", "oZEq7ovRbLq6UnGMPwc8B6",
+		"zzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := DecodeShort(s); err == nil {
+			t.Errorf("decoded invalid shortuuid %q", s)
+		}
+	}
+}
+
+func TestBase62(t *testing.T) {
+	if s := NamespaceDNS.EncodeBase62(); s != "3H8pGALtipnCnHud4zBiky" {
+		t.Errorf("Failed to encode base62. Expected: 3H8pGALtipnCnHud4zBiky, "+
+			"Received: %s", s)
+	}
+	if s := Max.EncodeBase62(); s != "7n42DGM5Tflk9n8mt7Fhc7" {
+		t.Errorf("Failed to encode max base62. Expected: "+
+			"7n42DGM5Tflk9n8mt7Fhc7, Received: %s", s)
+	}
+
+	list := NewV7Batch(100)
+	encoded := make([]string, len(list))
+	for i, in := range list {
+		encoded[i] = in.EncodeBase62()
+		result, err := DecodeBase62(encoded[i])
+		if err != nil || !result.Equal(in) {
+			t.Fatalf("Failed to round trip base62. Expected: %s, "+
+				"Received: %s", in, result)
+		}
+	}
+	if !slices.IsSorted(encoded) {
+		t.Errorf("base62 forms do not sort in UUID order")
+	}
+
+	for _, s := range []string{"", "3H8pGALtipnCnHud4zBik-",
+		"7n42DGM5Tflk9n8mt7Fhc8", "zzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := DecodeBase62(s); err == nil {
+			t.Errorf("decoded invalid base62 UUID %q", s)
+		}
+	}
+}
+
+func TestProquint(t *testing.T) {
+	// the proquint of 127.0.0.1 from the specification is lusab-babad
+	in := UUID{0x7F, 0x00, 0x00, 0x01, 0x7F, 0x00, 0x00, 0x01,
+		0x7F, 0x00, 0x00, 0x01, 0xFF, 0xFF, 0x00, 0x00}
+	expected := "lusab-babad-lusab-babad-lusab-babad-zuzuz-babab"
+	if s := in.EncodeProquint(); s != expected {
+		t.Errorf("Failed to encode proquint. Expected: %s, Received: %s",
+			expected, s)
+	}
+	result, err := DecodeProquint(strings.ToUpper(expected))
+	if err != nil || !result.Equal(in) {
+		t.Errorf("Failed to decode proquint. Expected: %s, Received: %s",
+			in, result)
+	}
+
+	for i := 0; i < 100; i++ {
+		in := NewV4()
+		result, err := DecodeProquint(in.EncodeProquint())
+		if err != nil || !result.Equal(in) {
+			t.Fatalf("Failed to round trip proquint. Expected: %s, "+
+				"Received: %s", in, result)
+		}
+	}
+
+	for _, s := range []string{"", "lusab-babad",
+		"lusab-babad-lusab-babad-lusab-babad-zuzuz-baba",
+		"lusab-babad-lusab-babad-lusab-babad-zuzuz-babae",
+		"lusab-babad-lusab-babad-lusab-babad-zuzuz-babab-babab"} {
+		if _, err := DecodeProquint(s); err == nil {
+			t.Errorf("decoded invalid proquint UUID %q", s)
+		}
+	}
+}
+
+func TestTypeID(t *testing.T) {
+	// the example of the TypeID specification
+	in, _ := Parse("01890a5d-ac96-774b-bcce-b302099a8057")
+	id, err := TypeID("user", in)
+	if err != nil || id != "user_01h455vb4pex5vsknk084sn02q" {
+		t.Errorf("Failed to encode TypeID. Expected: "+
+			"user_01h455vb4pex5vsknk084sn02q, Received: %s", id)
+	}
+	prefix, result, err := ParseTypeID(id)
+	if err != nil || prefix != "user" || !result.Equal(in) {
+		t.Errorf("Failed to parse TypeID. Expected: user %s, Received: %s %s",
+			in, prefix, result)
+	}
+
+	if id, _ = TypeID("", Nil); id != "00000000000000000000000000" {
+		t.Errorf("Failed to encode TypeID without prefix. Expected: "+
+			"00000000000000000000000000, Received: %s", id)
+	}
+	id, _ = TypeID("api_key", Max)
+	if id != "api_key_7zzzzzzzzzzzzzzzzzzzzzzzzz" {
+		t.Errorf("Failed to encode max TypeID. Expected: "+
+			"api_key_7zzzzzzzzzzzzzzzzzzzzzzzzz, Received: %s", id)
+	}
+	prefix, result, err = ParseTypeID("api_key_7zzzzzzzzzzzzzzzzzzzzzzzzz")
+	if err != nil || prefix != "api_key" || !result.Equal(Max) {
+		t.Errorf("Failed to parse TypeID with underscore in prefix: %s %s",
+			prefix, result)
+	}
+
+	for _, prefix := range []string{"User", "_user", "user_", "us3r",
+		strings.Repeat("a", 64)} {
+		if _, err := TypeID(prefix, in); err == nil {
+			t.Errorf("encoded TypeID with invalid prefix %q", prefix)
+		}
+	}
+	for _, s := range []string{"", "user_", "_01h455vb4pex5vsknk084sn02q",
+		"user_01H455VB4PEX5VSKNK084SN02Q", "user_81h455vb4pex5vsknk084sn02q",
+		"user_01h455vb4pex5vsknk084sn02", "user_01h455vb4pex5vsknk084sn0lq",
+		"User_01h455vb4pex5vsknk084sn02q"} {
+		if _, _, err := ParseTypeID(s); err == nil {
+			t.Errorf("parsed invalid TypeID %q", s)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrMD5Disabled is returned when a Version 3 UUID is requested in FIPS mode.
+var ErrMD5Disabled = errors.New("uuid: MD5-based Version 3 UUIDs are " +
+	"disabled in FIPS mode")
+
+// fipsMode is disabled by default, see SetFIPSMode.
+var fipsMode atomic.Bool
+
+// SetFIPSMode enables or disables FIPS mode. In FIPS mode MD5 is never used:
+// NewV3E and New(Version3) return ErrMD5Disabled, NewV3 and NewV3Hash panic
+// with it, and NewNameBased generates SHA-256 Version 8 UUIDs. FIPS mode is
+// not derived from crypto/fips140, it must be enabled by the application.
+func SetFIPSMode(enabled bool) {
+	fipsMode.Store(enabled)
+}
+
+// FIPSMode reports whether FIPS mode is enabled.
+func FIPSMode() bool {
+	return fipsMode.Load()
+}
+
+// NewNameBased generates the preferred name-based UUID for the namespace UUID
+// and name: a Version 5 UUID, or a SHA-256 Version 8 UUID in FIPS mode.
+// Returns 128-bit / 16 byte array representing the UUID.
+func NewNameBased(namespaceUUID UUID, name string) UUID {
+	if FIPSMode() {
+		return NewV8SHA256(namespaceUUID, name)
+	}
+
+	return NewV5(namespaceUUID, name)
+}
@@ -0,0 +1,49 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFIPSMode(t *testing.T) {
+	if FIPSMode() {
+		t.Fatalf("FIPS mode enabled by default")
+	}
+
+	SetFIPSMode(true)
+	defer SetFIPSMode(false)
+
+	if _, err := NewV3E(NamespaceDNS, "test"); !errors.Is(err, ErrMD5Disabled) {
+		t.Errorf("generated a version 3 UUID in FIPS mode")
+	}
+	if _, err := New(Version3, WithName("test")); !errors.Is(err, ErrMD5Disabled) {
+		t.Errorf("generated a version 3 UUID with New in FIPS mode")
+	}
+
+	if result := NewNameBased(NamespaceDNS, "test"); result.Version() != Version8 {
+		t.Errorf("incorrect FIPS mode name-based version: %s",
+			result.Version())
+	}
+	for _, md5 := range []func(){
+		func() { NewV3(NamespaceDNS, "test") },
+		func() { NewV3Hash(NamespaceDNS) },
+	} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrMD5Disabled) {
+					t.Errorf("Failed to refuse MD5 in FIPS mode. Expected: %v, "+
+						"Received: %v", ErrMD5Disabled, err)
+				}
+			}()
+			md5()
+		}()
+	}
+
+	SetFIPSMode(false)
+	if result := NewNameBased(NamespaceDNS, "test"); result.Version() != Version5 {
+		t.Errorf("incorrect name-based version: %s", result.Version())
+	}
+	if _, err := NewV3E(NamespaceDNS, "test"); err != nil {
+		t.Errorf("failed to generate a version 3 UUID: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding"
+	"fmt"
+)
+
+var _ encoding.TextAppender = UUID(nil)
+
+const (
+	lowerHexDigits = "0123456789abcdef"
+	upperHexDigits = "0123456789ABCDEF"
+)
+
+// urnPrefix is the namespace identifier for UUID URNs (RFC 4122 section 3).
+const urnPrefix = "urn:uuid:"
+
+// FormatStyle selects the textual layout produced by FormatAs.
+type FormatStyle int
+
+const (
+	// FormatLower is the canonical lowercase form,
+	// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+	FormatLower FormatStyle = iota
+	// FormatUpper is the canonical form in uppercase.
+	FormatUpper
+	// FormatHex is the 32 lowercase hex digits without dashes.
+	FormatHex
+	// FormatRegistry is the uppercase form wrapped in braces as used by the
+	// Windows registry, {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}.
+	FormatRegistry
+)
+
+// String returns the canonical xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form of
+// uuid, implementing fmt.Stringer. A uuid of the wrong length is rendered as
+// in PrintUUID.
+func (uuid UUID) String() string {
+	return PrintUUID(uuid)
+}
+
+// Format implements fmt.Formatter. The %s and %v verbs print the canonical
+// form, %q prints the canonical form in double quotes, and %x and %X print
+// the 32 hex digits without dashes in lower and upper case. Width and flags
+// are honored as they would be for the underlying string or bytes.
+func (uuid UUID) Format(f fmt.State, verb rune) {
+	if uuid == nil {
+		uuid = Nil
+	}
+
+	switch verb {
+	case 's', 'v', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), uuid.String())
+	case 'x', 'X':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), []byte(uuid))
+	default:
+		fmt.Fprintf(f, "%%!%c(uuid.UUID=%s)", verb, uuid.String())
+	}
+}
+
+// FormatAs returns uuid rendered in the given style. Unknown styles fall back
+// to FormatLower. A uuid that is not 16 bytes long is rendered as in
+// PrintUUID, whatever the style.
+func (uuid UUID) FormatAs(style FormatStyle) string {
+	if uuid == nil {
+		uuid = Nil
+	}
+	if len(uuid) != 16 {
+		return invalidString(uuid)
+	}
+
+	var buf [38]byte
+	switch style {
+	case FormatUpper:
+		return string(appendHex(buf[:0], uuid, upperHexDigits, true))
+	case FormatHex:
+		return string(appendHex(buf[:0], uuid, lowerHexDigits, false))
+	case FormatRegistry:
+		result := append(buf[:0], '{')
+		result = appendHex(result, uuid, upperHexDigits, true)
+		return string(append(result, '}'))
+	}
+
+	return uuid.String()
+}
+
+// URN returns uuid as a RFC 4122 uniform resource name,
+// urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func (uuid UUID) URN() string {
+	return urnPrefix + uuid.String()
+}
+
+// BracedString returns uuid in the uppercase braced form used by the Windows
+// registry and COM APIs, {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}.
+func (uuid UUID) BracedString() string {
+	return uuid.FormatAs(FormatRegistry)
+}
+
+// AppendText appends the canonical form of uuid to dst and returns the
+// extended buffer, implementing encoding.TextAppender. Unlike String it does
+// not allocate when dst has room for 36 more bytes.
+func (uuid UUID) AppendText(dst []byte) ([]byte, error) {
+	if uuid == nil {
+		uuid = Nil
+	}
+	if len(uuid) != 16 {
+		return dst, fmt.Errorf("uuid: invalid UUID length %d", len(uuid))
+	}
+
+	return appendHex(dst, uuid, lowerHexDigits, true), nil
+}
+
+// invalidString renders a uuid of the wrong length with its raw hex digits,
+// so printing one, e.g. in a log line, shows what it holds instead of
+// panicking.
+func invalidString(uuid []byte) string {
+	return fmt.Sprintf("invalid-uuid(%x)", uuid)
+}
+
+// appendHex appends the 16 bytes of uuid to dst as hex digits, inserting
+// dashes between the fields when dashes is true.
+func appendHex(dst []byte, uuid []byte, digits string, dashes bool) []byte {
+	for i, b := range uuid[:16] {
+		if dashes && (i == 4 || i == 6 || i == 8 || i == 10) {
+			dst = append(dst, '-')
+		}
+		dst = append(dst, digits[b>>4], digits[b&0x0F])
+	}
+
+	return dst
+}
@@ -0,0 +1,158 @@
+package uuid
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	result := NewV4()
+	if result.String() != PrintUUID(result) {
+		t.Errorf("Failed to stringify UUID. Expected: %s, Received: %s",
+			PrintUUID(result), result.String())
+	}
+
+	if s := fmt.Sprintf("%v", result); s != PrintUUID(result) {
+		t.Errorf("Failed to format UUID with %%v. Expected: %s, "+
+			"Received: %s", PrintUUID(result), s)
+	}
+
+	if s := UUID(nil).String(); s != NilUUID {
+		t.Errorf("Failed to stringify a nil UUID. Expected: %s, "+
+			"Received: %s", NilUUID, s)
+	}
+
+	expected := map[string]UUID{
+		"invalid-uuid()":       {},
+		"invalid-uuid(0a0b0c)": {0x0A, 0x0B, 0x0C},
+	}
+	for want, invalid := range expected {
+		if s := invalid.String(); s != want {
+			t.Errorf("Failed to stringify an invalid UUID. Expected: %s, "+
+				"Received: %s", want, s)
+		}
+		if s := fmt.Sprintf("%s %X", invalid, invalid); s != want+" "+
+			strings.ToUpper(want[13:len(want)-1]) {
+			t.Errorf("Failed to format an invalid UUID: %s", s)
+		}
+		for _, style := range []FormatStyle{FormatUpper, FormatRegistry} {
+			if s := invalid.FormatAs(style); s != want {
+				t.Errorf("Failed to format an invalid UUID in style %d. "+
+					"Expected: %s, Received: %s", style, want, s)
+			}
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	result, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	expected := map[string]string{
+		"%s":   "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"%v":   "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"%q":   `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		"%x":   "6ba7b8109dad11d180b400c04fd430c8",
+		"%X":   "6BA7B8109DAD11D180B400C04FD430C8",
+		"%d":   "%!d(uuid.UUID=6ba7b810-9dad-11d1-80b4-00c04fd430c8)",
+		"%38s": "  6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	}
+	for format, want := range expected {
+		if s := fmt.Sprintf(format, result); s != want {
+			t.Errorf("Failed to format UUID with %s. Expected: %s, "+
+				"Received: %s", format, want, s)
+		}
+	}
+}
+
+func TestFormatAs(t *testing.T) {
+	result, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	expected := map[FormatStyle]string{
+		FormatLower:    "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		FormatUpper:    "6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		FormatHex:      "6ba7b8109dad11d180b400c04fd430c8",
+		FormatRegistry: "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}",
+	}
+	for style, want := range expected {
+		if s := result.FormatAs(style); s != want {
+			t.Errorf("Failed to format UUID in style %d. Expected: %s, "+
+				"Received: %s", style, want, s)
+		}
+	}
+}
+
+func TestURN(t *testing.T) {
+	result := NewV4()
+	expected := "urn:uuid:" + PrintUUID(result)
+	if result.URN() != expected {
+		t.Errorf("Failed to print URN. Expected: %s, Received: %s",
+			expected, result.URN())
+	}
+
+	parsed, err := Parse(strings.ToUpper(result.URN()))
+	if err != nil {
+		t.Fatalf("failed to parse URN: %v", err)
+	}
+	if !bytes.Equal(parsed, result) {
+		t.Errorf("Failed to parse URN. Expected: %s, Received: %s",
+			result, parsed)
+	}
+}
+
+func TestBracedString(t *testing.T) {
+	result, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	expected := "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}"
+	if result.BracedString() != expected {
+		t.Errorf("Failed to print braced GUID. Expected: %s, Received: %s",
+			expected, result.BracedString())
+	}
+
+	parsed, err := Parse(expected)
+	if err != nil {
+		t.Fatalf("failed to parse braced GUID: %v", err)
+	}
+	if !bytes.Equal(parsed, result) {
+		t.Errorf("Failed to parse braced GUID. Expected: %s, Received: %s",
+			result, parsed)
+	}
+
+	if IsValid("{6ba7b810-9dad-11d1-80b4-00c04fd430c8") {
+		t.Errorf("accepted a GUID with an unbalanced brace")
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	result := NewV4()
+
+	buf := []byte("id=")
+	buf, err := result.AppendText(buf)
+	if err != nil {
+		t.Fatalf("failed to append UUID: %v", err)
+	}
+	if string(buf) != "id="+PrintUUID(result) {
+		t.Errorf("Failed to append UUID. Expected: id=%s, Received: %s",
+			PrintUUID(result), buf)
+	}
+
+	if _, err := (UUID{0x01}).AppendText(nil); err == nil {
+		t.Errorf("appended a UUID of invalid length")
+	}
+
+	dst := make([]byte, 0, 36)
+	allocs := testing.AllocsPerRun(100, func() {
+		result.AppendText(dst)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendText allocated %v times", allocs)
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	result := NewV4()
+	dst := make([]byte, 0, 36)
+	for i := 0; i < b.N; i++ {
+		result.AppendText(dst)
+	}
+}
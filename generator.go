@@ -0,0 +1,250 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Generator holds the state used to generate UUIDs: the timestamp, clock
+// sequence and node of the time-based versions, the source of random bits
+// and the default namespace. The package-level functions use a default
+// Generator; NewGenerator and NewSeededGenerator create independent ones, so
+// libraries can hold generators with their own configuration. The zero value
+// is ready to use with the system clock, crypto/rand and a random node ID. A
+// Generator is safe for concurrent use.
+type Generator struct {
+	mu        sync.Mutex
+	clock     uint16
+	node      []byte
+	namespace UUID
+
+	// Version 1, 2 and 6 state updated without the lock, see
+	// reserveTimestamps
+	v1Last atomic.Uint64
+	v1Tick atomic.Uint64
+	v1Seq  atomic.Pointer[v1Sequence]
+
+	// node ID selected when the Generator was created and how it was
+	// acquired, restored when privacy mode is disabled
+	baseNode       []byte
+	baseNodeSource NodeSource
+	nodeSource     NodeSource
+
+	// errors of the random source while choosing the clock sequence, the
+	// base node ID and the current node ID, see publishSequence
+	clockErr    error
+	baseNodeErr error
+	nodeErr     error
+
+	// node chain and privacy mode applied on first use, see ensureNode, and
+	// the random namespace generated on first use, see Namespace
+	nodeOnce      sync.Once
+	nodeChain     []NodeStrategy
+	privacyNode   bool
+	namespaceOnce sync.Once
+
+	// source of random bits, see SetRand
+	rand atomic.Pointer[io.Reader]
+	// buffered random bits for Version 4, nil unless EnableRandPool is called
+	pool atomic.Pointer[randPool]
+	// behavior when the random source fails, see SetEntropyPolicy
+	entropyPolicy atomic.Int32
+	// number of failed reads from the random source, see Health
+	randErrors atomic.Uint64
+	// external generator of Version 4 UUIDs, see SetBackend
+	backend atomic.Pointer[Backend]
+	// number of failed calls to the Backend, see Health
+	backendErrors atomic.Uint64
+
+	// clock sequence range held by the process, see LeaseClockSequence
+	lease *clockLease
+
+	// stable storage of the time-based state, see SetStableStorage
+	state       StableStorage
+	stateErrors atomic.Uint64
+
+	// counters reported by Stats
+	generated     [Version8 + 1]atomic.Uint64
+	clockChanges  atomic.Uint64
+	poolRefills   atomic.Uint64
+	counterStalls atomic.Uint64
+
+	// behavior when the timestamp counter is exhausted, see
+	// SetCounterPolicy
+	counterPolicy atomic.Int32
+
+	// source of the current time, see WithClock
+	timeSource Clock
+
+	// Version 7 state, see reserveV7
+	v7Last      atomic.Uint64
+	v7Shared    atomic.Bool
+	v7Precision atomic.Int32
+}
+
+// u is the Generator used by the package-level functions unless SetDefault
+// replaces it.
+var u = NewGenerator()
+
+// defaultGenerator is the Generator used by the package-level functions, nil
+// for u.
+var defaultGenerator atomic.Pointer[Generator]
+
+// SetDefault makes the package-level functions, such as NewV1 and NewV4, use
+// g, so applications can configure privacy mode, the random source and the
+// clock once at startup. Passing nil restores the Generator created when the
+// package was loaded.
+func SetDefault(g *Generator) {
+	defaultGenerator.Store(g)
+}
+
+// Default returns the Generator used by the package-level functions.
+func Default() *Generator {
+	if g := defaultGenerator.Load(); g != nil {
+		return g
+	}
+
+	return u
+}
+
+// NewGenerator returns a Generator with its own state, configured with
+// WithNodeID, WithHostnameNode, WithInterfacePolicy, WithNodeChain,
+// WithClock, WithRand, WithBackend, WithNamespace, WithPrivacyNode and
+// WithV7Monotonic. By default the node ID is acquired by DefaultNodeChain,
+// random bits come from crypto/rand, Version 4 UUIDs are generated in
+// process and the namespace is random. WithNodeID and
+// WithHostnameNode are tried before the node chain. The node ID, clock
+// sequence and namespace are acquired on first use, so creating a Generator
+// makes no system calls.
+func NewGenerator(opts ...Option) *Generator {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	g := &Generator{timeSource: systemClock}
+	if o.clock != nil {
+		g.timeSource = o.clock
+	}
+	if o.rand != nil {
+		g.setRand(o.rand)
+	}
+	g.SetBackend(o.backend)
+	chain := o.nodeChain
+	if chain == nil {
+		policy := DefaultInterfacePolicy
+		if o.interfacePolicy != nil {
+			policy = *o.interfacePolicy
+		}
+		chain = defaultNodeChain(policy)
+	}
+	if o.hostnameSalt != nil {
+		chain = append([]NodeStrategy{HostnameNode(*o.hostnameSalt)}, chain...)
+	}
+	if o.nodeID != nil {
+		chain = append([]NodeStrategy{StaticNode([6]byte(o.nodeID))}, chain...)
+	}
+	g.nodeChain, g.privacyNode = chain, o.privacyNode
+	g.v7Shared.Store(o.v7Monotonic)
+	g.namespace = append(UUID(nil), o.namespace...)
+
+	return g
+}
+
+// clockSource returns the Clock of g, the system clock for the zero value.
+func (g *Generator) clockSource() Clock {
+	if g.timeSource == nil {
+		return systemClock
+	}
+
+	return g.timeSource
+}
+
+// ensureNode acquires the node ID and clock sequence of time-based UUIDs, if
+// that has not happened yet. Must not be called with g.mu held.
+func (g *Generator) ensureNode() {
+	// nodeChain is only read and cleared inside Do, which orders it with
+	// every other first use
+	g.nodeOnce.Do(func() {
+		g.acquireNode(g.nodeChain)
+	})
+}
+
+// acquireNodeOnce acquires the node ID from chain instead of the node chain of
+// g unless the node ID has been acquired already, and reports whether it did.
+// Setters of the node ID use it to skip the node chain.
+func (g *Generator) acquireNodeOnce(chain []NodeStrategy) bool {
+	acquired := false
+	g.nodeOnce.Do(func() {
+		g.acquireNode(chain)
+		acquired = true
+	})
+
+	return acquired
+}
+
+// acquireNode sets the node ID from chain and a random clock sequence. Must
+// only be called inside g.nodeOnce. Errors of the random source are recorded
+// and returned by reserveTimestamps.
+func (g *Generator) acquireNode(chain []NodeStrategy) {
+	clock, clockErr := g.randomUint32()
+	node, source, nodeErr := g.chainNode(chain)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.clock, g.clockErr = uint16(clock), clockErr
+	g.baseNode, g.baseNodeSource, g.baseNodeErr = node, source, nodeErr
+	g.node, g.nodeSource, g.nodeErr = g.baseNode, g.baseNodeSource, g.baseNodeErr
+	if g.privacyNode {
+		g.node, g.nodeErr = g.randomNode()
+		g.nodeSource = NodePrivacy
+	}
+	g.nodeChain = nil
+	g.publishSequence()
+}
+
+// seededEpoch is the first time reported by the clock of a seeded Generator.
+var seededEpoch = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// NewSeededGenerator returns a Generator whose output is fully reproducible
+// for a given seed, for golden-file tests and simulations that assert exact
+// identifier values. Random bits come from InsecureChaCha8 and the clock
+// starts at 1 January 2020 and advances one millisecond each time it is
+// read, so the UUIDs must never be used where they need to be unguessable.
+func NewSeededGenerator(seed int64) *Generator {
+	var chaCha8Seed [32]byte
+	binary.BigEndian.PutUint64(chaCha8Seed[:], uint64(seed))
+
+	g := &Generator{timeSource: newSteppingClock(seededEpoch, time.Millisecond)}
+	g.setRand(InsecureChaCha8(chaCha8Seed))
+	// acquire the random state now, so it does not depend on the order of
+	// the first calls
+	g.ensureNode()
+	g.Namespace()
+
+	return g
+}
+
+// newSteppingClock returns a clock that starts at start and advances by step
+// each time it is read.
+func newSteppingClock(start time.Time, step time.Duration) Clock {
+	var mu sync.Mutex
+	next := start
+
+	return ClockFunc(func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		now := next
+		next = next.Add(step)
+		return now
+	})
+}
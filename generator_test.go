@@ -0,0 +1,228 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestNewGenerator(t *testing.T) {
+	g := NewGenerator()
+	if g.Namespace().Version() != Version4 {
+		t.Errorf("incorrect namespace version detected: %s",
+			g.Namespace().Version())
+	}
+
+	// configuring one generator must not affect the default
+	g.SetPrivacyNode(true)
+	defer g.SetPrivacyNode(false)
+	if g.Health().NodeSource != NodePrivacy {
+		t.Errorf("privacy node was not enabled on the generator")
+	}
+	if CheckHealth().NodeSource == NodePrivacy {
+		t.Errorf("privacy node leaked into the default generator")
+	}
+
+	result := g.NewV1()
+	if result[6]>>4 != 1 {
+		t.Fatalf("incorrect version number detected")
+	}
+	if !bytes.Equal(result[10:], g.currentNode()) {
+		t.Errorf("version 1 UUID does not embed the generator node")
+	}
+}
+
+func TestNewGeneratorOptions(t *testing.T) {
+	node := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithNodeID(node), WithNamespace(NamespaceDNS),
+		WithClock(FrozenClock(now)),
+		WithRand(InsecureChaCha8([32]byte{})))
+
+	if health := g.Health(); health.NodeSource != NodeStatic ||
+		health.RandSource != "chacha8" {
+		t.Errorf("options were not applied: %+v", health)
+	}
+	if !g.Namespace().Equal(NamespaceDNS) {
+		t.Errorf("Failed to set namespace. Expected: %s, Received: %s",
+			NamespaceDNS, g.Namespace())
+	}
+
+	result := g.NewV1()
+	if !bytes.Equal(result[10:], node[:]) {
+		t.Errorf("Failed to set node ID. Expected: %x, Received: %x",
+			node, result[10:])
+	}
+	result = g.NewV7()
+	millis := uint64(result[0])<<40 | uint64(result[1])<<32 |
+		uint64(result[2])<<24 | uint64(result[3])<<16 | uint64(result[4])<<8 |
+		uint64(result[5])
+	if millis != uint64(now.UnixMilli()) {
+		t.Errorf("Failed to set clock. Expected: %d, Received: %d",
+			now.UnixMilli(), millis)
+	}
+
+	g = NewGenerator(WithNodeID(node), WithPrivacyNode())
+	if g.Health().NodeSource != NodePrivacy ||
+		bytes.Equal(g.currentNode(), node[:]) {
+		t.Errorf("privacy node was not enabled")
+	}
+}
+
+// countingNode is a NodeStrategy counting how often it is asked for a node.
+type countingNode struct {
+	calls *int
+}
+
+func (c countingNode) Source() NodeSource {
+	return NodeStatic
+}
+
+func (c countingNode) Node() ([6]byte, bool) {
+	*c.calls++
+	return [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}, true
+}
+
+func TestNewGeneratorLazy(t *testing.T) {
+	var calls int
+	g := NewGenerator(WithNodeChain(countingNode{&calls}))
+	if calls != 0 || g.namespace != nil {
+		t.Fatalf("acquired the node ID or namespace on creation")
+	}
+
+	g.NewV4()
+	g.NewV7()
+	if calls != 0 || g.namespace != nil {
+		t.Errorf("acquired the node ID or namespace for random UUIDs")
+	}
+
+	g.NewV1()
+	g.NewV6()
+	if calls != 1 {
+		t.Errorf("Failed to acquire the node ID once. Expected: 1, "+
+			"Received: %d", calls)
+	}
+	if g.namespace != nil {
+		t.Errorf("acquired the namespace for time-based UUIDs")
+	}
+
+	if g.NewV5Name("test").Version() != Version5 || g.namespace == nil {
+		t.Errorf("Failed to generate the namespace on first use")
+	}
+
+	// setting the node ID before first use skips the chain
+	g = NewGenerator(WithNodeChain(countingNode{&calls}))
+	node := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x02}
+	if err := g.SetNodeID(node); err != nil || g.NodeID() != node || calls != 1 {
+		t.Errorf("Failed to set node ID before first use. Expected: %x, "+
+			"Received: %x", node, g.NodeID())
+	}
+}
+
+func TestNewGeneratorLazyConcurrent(t *testing.T) {
+	const goroutines = 8
+
+	var calls int
+	g := NewGenerator(WithNodeChain(countingNode{&calls}))
+	start := make(chan struct{})
+	results := make(chan UUID, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			<-start
+			results <- g.NewV1()
+		}()
+	}
+	close(start)
+
+	node := []byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}
+	for i := 0; i < goroutines; i++ {
+		if result := <-results; !bytes.Equal(result[10:], node) {
+			t.Errorf("Failed to embed node ID. Expected: %x, Received: %x",
+				node, result[10:])
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Failed to acquire the node ID once. Expected: 1, "+
+			"Received: %d", calls)
+	}
+}
+
+func TestGeneratorZeroValue(t *testing.T) {
+	var g Generator
+	for _, version := range []Version{Version1, Version2, Version3, Version4,
+		Version5, Version6, Version7, Version8} {
+		result, err := g.New(version, WithName("test"),
+			WithDomain(DomainPerson, 1000))
+		if err != nil || result.Version() != version {
+			t.Errorf("Failed to generate %s UUID with the zero value: %v",
+				version, err)
+		}
+	}
+	if health := g.Health(); health.NodeSource != NodeRandom {
+		t.Errorf("incorrect node source detected: %s", health.NodeSource)
+	}
+	g.NewV1At(time.Now())
+	g.NewCOMB()
+}
+
+func TestSetDefault(t *testing.T) {
+	node := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}
+	g := NewGenerator(WithNodeID(node))
+	SetDefault(g)
+	defer SetDefault(nil)
+
+	if Default() != g {
+		t.Fatalf("failed to replace the default generator")
+	}
+	if result := NewV1(); !bytes.Equal(result[10:], node[:]) {
+		t.Errorf("Failed to use the default generator. Expected: %x, "+
+			"Received: %x", node, result[10:])
+	}
+
+	SetDefault(nil)
+	if Default() != u {
+		t.Errorf("failed to restore the default generator")
+	}
+}
+
+func TestNewSeededGenerator(t *testing.T) {
+	// golden values, so the output stays the same across runs and releases
+	g := NewSeededGenerator(42)
+	golden := []struct {
+		expected string
+		result   UUID
+	}{
+		{"b387eee3-040e-41cb-835a-07164b6bbddb", g.NewV4()},
+		{"016f5e66-e800-7409-b565-fd618b4a32d3", g.NewV7()},
+	}
+	for _, test := range golden {
+		if s := test.result.String(); s != test.expected {
+			t.Errorf("Failed to reproduce golden UUID. Expected: %s, "+
+				"Received: %s", test.expected, s)
+		}
+	}
+
+	first := NewSeededGenerator(42)
+	second := NewSeededGenerator(42)
+
+	for i := 0; i < 100; i++ {
+		a, b := first.NewV4(), second.NewV4()
+		if !a.Equal(b) {
+			t.Fatalf("Failed to reproduce version 4 UUID %d. Expected: %s, "+
+				"Received: %s", i, a, b)
+		}
+
+		a, b = first.NewV7(), second.NewV7()
+		if !a.Equal(b) {
+			t.Fatalf("Failed to reproduce version 7 UUID %d. Expected: %s, "+
+				"Received: %s", i, a, b)
+		}
+		if a.Version() != Version7 {
+			t.Fatalf("incorrect version detected: %s", a.Version())
+		}
+	}
+
+	if NewSeededGenerator(43).NewV4().Equal(NewSeededGenerator(42).NewV4()) {
+		t.Errorf("different seeds produced the same UUID")
+	}
+}
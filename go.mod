@@ -0,0 +1,3 @@
+module github.com/edwardfward/gouuid
+
+go 1.24
@@ -0,0 +1,161 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"hash"
+	"io"
+)
+
+// Well known namespace UUIDs from RFC 4122 appendix C for use with the
+// name-based versions.
+var (
+	// NamespaceDNS is for fully qualified domain names.
+	NamespaceDNS = UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1,
+		0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	// NamespaceURL is for URLs.
+	NamespaceURL = UUID{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1,
+		0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	// NamespaceOID is for ISO object identifiers.
+	NamespaceOID = UUID{0x6b, 0xa7, 0xb8, 0x12, 0x9d, 0xad, 0x11, 0xd1,
+		0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	// NamespaceX500 is for X.500 distinguished names in DER or text form.
+	NamespaceX500 = UUID{0x6b, 0xa7, 0xb8, 0x14, 0x9d, 0xad, 0x11, 0xd1,
+		0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+)
+
+// NameHash computes a name-based UUID from a namespace and a name that is
+// written in pieces, so large names never have to be held in one contiguous
+// allocation. A NameHash implements io.Writer and io.ReaderFrom.
+type NameHash struct {
+	hash    hash.Hash
+	version Version
+}
+
+// NewV3Hash returns a NameHash producing Version 3 (MD5) UUIDs in the given
+// namespace. Panics with ErrMD5Disabled in FIPS mode.
+func NewV3Hash(namespaceUUID UUID) *NameHash {
+	if FIPSMode() {
+		panic(ErrMD5Disabled)
+	}
+
+	return newNameHash(md5.New(), Version3, namespaceUUID)
+}
+
+// NewV5Hash returns a NameHash producing Version 5 (SHA-1) UUIDs in the given
+// namespace.
+func NewV5Hash(namespaceUUID UUID) *NameHash {
+	return newNameHash(sha1.New(), Version5, namespaceUUID)
+}
+
+// NewV8SHA256Hash returns a NameHash producing Version 8 (SHA-256) UUIDs in
+// the given namespace, see NewV8SHA256.
+func NewV8SHA256Hash(namespaceUUID UUID) *NameHash {
+	return newNameHash(sha256.New(), Version8, namespaceUUID)
+}
+
+func newNameHash(h hash.Hash, version Version, namespaceUUID UUID) *NameHash {
+	h.Write(namespaceUUID)
+
+	return &NameHash{hash: h, version: version}
+}
+
+// Write adds more of the name to the hash. It never returns an error.
+func (h *NameHash) Write(p []byte) (int, error) {
+	return h.hash.Write(p)
+}
+
+// ReadFrom streams the rest of the name from r into the hash until EOF.
+func (h *NameHash) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(h.hash, r)
+}
+
+// UUID returns the UUID for the namespace and the name written so far. It
+// does not change the underlying hash, so more of the name can be written
+// afterwards.
+func (h *NameHash) UUID() UUID {
+	result := make(UUID, 16)
+	copy(result, h.hash.Sum(nil)) // only the first 128 bits are kept
+	result[6] = (result[6] & 0x0F) | byte(h.version)<<4
+	result[8] = (result[8] & 0x3F) | 0x80 // RFC 4122 variant
+
+	return result
+}
+
+// NewV5FromReader generates a Version 5 UUID in the given namespace whose
+// name is the entire content of r, e.g. a file, for content-addressed
+// identifiers. Returns an error if reading from r fails.
+func NewV5FromReader(namespaceUUID UUID, r io.Reader) (UUID, error) {
+	hash := NewV5Hash(namespaceUUID)
+	if _, err := hash.ReadFrom(r); err != nil {
+		return nil, err
+	}
+
+	return hash.UUID(), nil
+}
+
+// SetNamespace sets the default namespace of the package-level functions,
+// used by NewV5Name and by New when WithNamespace is not given. The namespace
+// is copied, so later changes to namespaceUUID have no effect.
+func SetNamespace(namespaceUUID UUID) {
+	Default().SetNamespace(namespaceUUID)
+}
+
+// SetNamespace sets the default namespace of g, see the package-level
+// SetNamespace.
+func (g *Generator) SetNamespace(namespaceUUID UUID) {
+	g.mu.Lock()
+	g.namespace = append(UUID(nil), namespaceUUID...)
+	g.mu.Unlock()
+}
+
+// Namespace returns the default namespace of the package-level functions.
+func Namespace() UUID {
+	return Default().Namespace()
+}
+
+// Namespace returns the default namespace of g, which is random unless set
+// with WithNamespace or SetNamespace. The random namespace is generated on
+// first use. The result is a copy, so callers may modify it.
+func (g *Generator) Namespace() UUID {
+	g.namespaceOnce.Do(func() {
+		g.mu.Lock()
+		missing := g.namespace == nil
+		g.mu.Unlock()
+		if !missing {
+			return
+		}
+
+		// generate random uuid namespace in case one's not provided
+		namespace := g.NewV4()
+		g.mu.Lock()
+		if g.namespace == nil {
+			g.namespace = namespace
+		}
+		g.mu.Unlock()
+	})
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append(UUID(nil), g.namespace...)
+}
+
+// NewV5Name generates a Version 5 UUID for name in the default namespace, so
+// services deriving deterministic identifiers set the namespace once with
+// SetNamespace.
+func NewV5Name(name string) UUID {
+	return Default().NewV5Name(name)
+}
+
+// NewV5Name generates a Version 5 UUID for name in the default namespace of
+// g.
+func (g *Generator) NewV5Name(name string) UUID {
+	g.countGenerated(Version5, 1)
+	return NewV5(g.Namespace(), name)
+}
@@ -0,0 +1,117 @@
+package uuid
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNamespaces(t *testing.T) {
+	expected := map[string]UUID{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8": NamespaceDNS,
+		"6ba7b811-9dad-11d1-80b4-00c04fd430c8": NamespaceURL,
+		"6ba7b812-9dad-11d1-80b4-00c04fd430c8": NamespaceOID,
+		"6ba7b814-9dad-11d1-80b4-00c04fd430c8": NamespaceX500,
+	}
+	for want, namespace := range expected {
+		if PrintUUID(namespace) != want {
+			t.Errorf("incorrect namespace. Expected: %s, Received: %s", want,
+				PrintUUID(namespace))
+		}
+	}
+}
+
+func TestNameHash(t *testing.T) {
+	// test vectors from the RFC 4122 errata and RFC 9562 appendix A.2 and A.4
+	dns := NamespaceDNS
+	if s := PrintUUID(NewV3(dns, "www.example.com")); s != "5df41881-3aed-3515-88a7-2f4a814cf09e" {
+		t.Errorf("incorrect version 3 UUID: %s", s)
+	}
+	if s := PrintUUID(NewV5(dns, "www.example.com")); s != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Errorf("incorrect version 5 UUID: %s", s)
+	}
+
+	// writing the name in pieces matches hashing the whole name
+	hash := NewV5Hash(dns)
+	hash.Write([]byte("www."))
+	if _, err := hash.ReadFrom(strings.NewReader("example.com")); err != nil {
+		t.Fatalf("failed to read name: %v", err)
+	}
+	if result := hash.UUID(); !result.Equal(NewV5(dns, "www.example.com")) {
+		t.Errorf("Failed to stream name. Expected: %s, Received: %s",
+			NewV5(dns, "www.example.com"), result)
+	}
+
+	// the namespace UUID must not be modified by hashing
+	namespace := make(UUID, 16, 64)
+	NewV3(namespace, "test")
+	NewV5(namespace, "test")
+	if !namespace[:64][16:32].IsNil() {
+		t.Errorf("hashing wrote into the namespace UUID's backing array")
+	}
+}
+
+func TestNewV5FromReader(t *testing.T) {
+	content := strings.Repeat("artifact", 100000)
+
+	result, err := NewV5FromReader(Namespace(), strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("failed to read content: %v", err)
+	}
+	if expected := NewV5(Namespace(), content); !result.Equal(expected) {
+		t.Errorf("Failed to hash content. Expected: %s, Received: %s",
+			expected, result)
+	}
+
+	if _, err := NewV5FromReader(Namespace(), iotest.ErrReader(io.ErrUnexpectedEOF)); err == nil {
+		t.Errorf("ignored a read error")
+	}
+}
+
+func TestNewV5Name(t *testing.T) {
+	g := NewGenerator(WithNamespace(NamespaceDNS))
+	expected := "886313e1-3b8a-5372-9b90-0c9aee199e5d"
+	if result := g.NewV5Name("python.org").String(); result != expected {
+		t.Errorf("Failed to use default namespace. Expected: %s, Received: %s",
+			expected, result)
+	}
+
+	g.SetNamespace(NamespaceURL)
+	if !g.Namespace().Equal(NamespaceURL) {
+		t.Errorf("Failed to set namespace. Expected: %s, Received: %s",
+			NamespaceURL, g.Namespace())
+	}
+	if result := g.NewV5Name("python.org"); !result.Equal(NewV5(NamespaceURL, "python.org")) {
+		t.Errorf("Failed to use updated namespace. Received: %s", result)
+	}
+	if result, _ := g.New(Version5, WithName("python.org")); !result.Equal(g.NewV5Name("python.org")) {
+		t.Errorf("New ignored the default namespace. Received: %s", result)
+	}
+}
+
+func TestNamespaceCopy(t *testing.T) {
+	namespace := append(UUID(nil), NamespaceDNS...)
+	g := NewGenerator(WithNamespace(namespace))
+	namespace[0] ^= 0xFF
+	if !g.Namespace().Equal(NamespaceDNS) {
+		t.Errorf("Failed to copy namespace. Expected: %s, Received: %s",
+			NamespaceDNS, g.Namespace())
+	}
+
+	g.SetNamespace(namespace)
+	namespace[0] ^= 0xFF
+	if g.Namespace().Equal(NamespaceDNS) {
+		t.Errorf("Failed to copy namespace on set. Received: %s",
+			g.Namespace())
+	}
+
+	g.SetNamespace(NamespaceURL)
+	g.Namespace()[0] ^= 0xFF
+	if !g.Namespace().Equal(NamespaceURL) || !NamespaceURL.Equal(UUID{
+		0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1,
+		0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}) {
+		t.Errorf("Failed to copy namespace on get. Expected: %s, "+
+			"Received: %s", NamespaceURL, g.Namespace())
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	crand "crypto/rand"
+	"fmt"
+)
+
+// NodeSource describes how the node ID embedded in time-based UUIDs was
+// acquired.
+type NodeSource string
+
+const (
+	// NodeHardware is the hardware address of a network interface, see
+	// InterfacePolicy.
+	NodeHardware NodeSource = "hardware"
+	// NodeRandom is a random node ID, used when no network interface
+	// qualified.
+	NodeRandom NodeSource = "random"
+	// NodePrivacy is a random node ID selected with SetPrivacyNode.
+	NodePrivacy NodeSource = "privacy"
+	// NodeHostname is a node ID derived from the hostname with
+	// WithHostnameNode.
+	NodeHostname NodeSource = "hostname"
+	// NodeEnv is a node ID read from an environment variable, see EnvNode.
+	NodeEnv NodeSource = "env"
+	// NodeStatic is a node ID set with WithNodeID or SetNodeID.
+	NodeStatic NodeSource = "static"
+)
+
+// Health reports how a Generator is producing UUIDs, so operators can verify
+// at startup that identifiers are being generated securely.
+type Health struct {
+	// NodeSource is how the node ID of time-based UUIDs was acquired.
+	NodeSource NodeSource
+	// RandSource names the source of random bits: crypto/rand, rdrand,
+	// math/rand, chacha8 or, for sources set with SetRand, their Go type.
+	RandSource string
+	// SecureRand reports whether RandSource is a cryptographically secure
+	// source known to this package.
+	SecureRand bool
+	// RandPool reports whether the buffered random pool is enabled.
+	RandPool bool
+	// Backend names the Backend generating Version 4 UUIDs, kernel,
+	// cocreateguid or the Go type of a custom Backend, and is empty when
+	// they are generated in process.
+	Backend string
+	// BackendErrors is the number of failed calls to the Backend, each
+	// answered with an in-process Version 4 UUID instead.
+	BackendErrors uint64
+	// RandErrors is the number of failed reads from the random source.
+	RandErrors uint64
+	// StateErrors is the number of failed writes to stable storage, see
+	// SetStableStorage.
+	StateErrors uint64
+}
+
+// CheckHealth reports how the package-level functions are producing UUIDs.
+func CheckHealth() Health {
+	return Default().Health()
+}
+
+// Health reports how g is producing UUIDs.
+func (g *Generator) Health() Health {
+	g.ensureNode()
+
+	g.mu.Lock()
+	health := Health{NodeSource: g.nodeSource}
+	g.mu.Unlock()
+
+	health.RandSource, health.SecureRand = "crypto/rand", true
+	if r := g.rand.Load(); r != nil {
+		switch (*r).(type) {
+		case rdrandReader:
+			health.RandSource = "rdrand"
+		case mathRandReader:
+			health.RandSource, health.SecureRand = "math/rand", false
+		case *chaCha8Reader:
+			health.RandSource, health.SecureRand = "chacha8", false
+		default:
+			if *r != crand.Reader {
+				health.RandSource = fmt.Sprintf("%T", *r)
+				health.SecureRand = false
+			}
+		}
+	}
+
+	health.RandPool = g.pool.Load() != nil
+
+	if backend := g.backend.Load(); backend != nil {
+		switch (*backend).(type) {
+		case kernelBackend:
+			health.Backend = "kernel"
+		case coCreateGuidBackend:
+			health.Backend = "cocreateguid"
+		default:
+			health.Backend = fmt.Sprintf("%T", *backend)
+		}
+	}
+
+	health.BackendErrors = g.backendErrors.Load()
+	health.RandErrors = g.randErrors.Load()
+	health.StateErrors = g.stateErrors.Load()
+
+	return health
+}
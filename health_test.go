@@ -0,0 +1,50 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestCheckHealth(t *testing.T) {
+	health := CheckHealth()
+	if health.RandSource != "crypto/rand" || !health.SecureRand {
+		t.Errorf("incorrect default random source: %+v", health)
+	}
+	switch health.NodeSource {
+	case NodeEnv, NodeHardware, NodeHostname, NodeRandom:
+	default:
+		t.Errorf("incorrect default node source: %s", health.NodeSource)
+	}
+	if health.RandPool || health.Backend != "" {
+		t.Errorf("incorrect default pool or backend: %+v", health)
+	}
+
+	SetPrivacyNode(true)
+	SetInsecureRand(true)
+	SetBackend(NewKernelBackend())
+	EnableRandPool()
+	health = CheckHealth()
+	SetPrivacyNode(false)
+	SetInsecureRand(false)
+	SetBackend(nil)
+	DisableRandPool()
+
+	if health.NodeSource != NodePrivacy {
+		t.Errorf("incorrect privacy node source: %s", health.NodeSource)
+	}
+	if health.RandSource != "math/rand" || health.SecureRand {
+		t.Errorf("incorrect insecure random source: %+v", health)
+	}
+	if health.Backend != "kernel" || !health.RandPool {
+		t.Errorf("incorrect pool or backend: %+v", health)
+	}
+
+	// failed reads are counted
+	before := CheckHealth().RandErrors
+	SetRand(&flakyReader{failures: 1})
+	NewV4E()
+	SetRand(nil)
+	if errors := CheckHealth().RandErrors; errors != before+1 {
+		t.Errorf("incorrect random error count. Expected: %d, Received: %d",
+			before+1, errors)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// ToBigInt returns uuid as an unsigned 128-bit integer, its bytes taken in
+// big-endian order, e.g. to store it in a NUMERIC(39) column. Panics if uuid
+// is not 16 bytes long.
+func (uuid UUID) ToBigInt() *big.Int {
+	array := uuid.Array()
+	return new(big.Int).SetBytes(array[:])
+}
+
+// FromBigInt converts an unsigned 128-bit integer, as returned by ToBigInt,
+// into a UUID. Returns an error if n is negative or does not fit in 128 bits.
+func FromBigInt(n *big.Int) (UUID, error) {
+	if n.Sign() < 0 || n.BitLen() > 128 {
+		return nil, fmt.Errorf("uuid: %s is not an unsigned 128-bit integer", n)
+	}
+
+	return n.FillBytes(make(UUID, 16)), nil
+}
+
+// ToUint64Pair returns the high and low 64 bits of uuid, its bytes taken in
+// big-endian order, e.g. to store it in two integer columns. Comparing the
+// pairs, high first, orders them as Compare orders the UUIDs. Panics if uuid
+// is not 16 bytes long.
+func (uuid UUID) ToUint64Pair() (hi, lo uint64) {
+	array := uuid.Array()
+	return binary.BigEndian.Uint64(array[:8]), binary.BigEndian.Uint64(array[8:])
+}
+
+// FromUint64Pair returns the UUID whose high and low 64 bits are hi and lo,
+// the inverse of ToUint64Pair.
+func FromUint64Pair(hi, lo uint64) UUID {
+	uuid := make(UUID, 16)
+	binary.BigEndian.PutUint64(uuid[:8], hi)
+	binary.BigEndian.PutUint64(uuid[8:], lo)
+
+	return uuid
+}
+
+// ToInt64Pair returns the high and low 64 bits of uuid as two's complement
+// signed integers, the layout of java.util.UUID and of schemas storing UUIDs
+// in two BIGINT columns. The conversion is lossless, but signed order differs
+// from UUID order whenever the top bit of a half is set: such halves are
+// negative and sort first. Schemas that must sort in UUID order should store
+// each half with its top bit flipped, i.e. int64(hi ^ 1<<63) from
+// ToUint64Pair. Panics if uuid is not 16 bytes long.
+func (uuid UUID) ToInt64Pair() (hi, lo int64) {
+	uhi, ulo := uuid.ToUint64Pair()
+	return int64(uhi), int64(ulo)
+}
+
+// FromInt64Pair returns the UUID whose high and low 64 bits are the two's
+// complement of hi and lo, the inverse of ToInt64Pair.
+func FromInt64Pair(hi, lo int64) UUID {
+	return FromUint64Pair(uint64(hi), uint64(lo))
+}
@@ -0,0 +1,68 @@
+package uuid
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	expected, _ := new(big.Int).SetString(
+		"143098242404177361603877621312831893704", 10)
+	if n := NamespaceDNS.ToBigInt(); n.Cmp(expected) != 0 {
+		t.Errorf("Failed to convert to big.Int. Expected: %s, Received: %s",
+			expected, n)
+	}
+
+	for _, in := range []UUID{NamespaceDNS, Nil, Max, NewV4()} {
+		result, err := FromBigInt(in.ToBigInt())
+		if err != nil || !result.Equal(in) {
+			t.Errorf("Failed to round trip big.Int. Expected: %s, "+
+				"Received: %s", in, result)
+		}
+	}
+
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 128)
+	for _, n := range []*big.Int{big.NewInt(-1), tooLarge} {
+		if _, err := FromBigInt(n); err == nil {
+			t.Errorf("converted %s", n)
+		}
+	}
+}
+
+func TestUint64Pair(t *testing.T) {
+	hi, lo := NamespaceDNS.ToUint64Pair()
+	if hi != 0x6ba7b8109dad11d1 || lo != 0x80b400c04fd430c8 {
+		t.Errorf("Failed to convert to uint64 pair. Expected: "+
+			"6ba7b8109dad11d1 80b400c04fd430c8, Received: %x %x", hi, lo)
+	}
+	if result := FromUint64Pair(hi, lo); !result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to convert from uint64 pair. Expected: %s, "+
+			"Received: %s", NamespaceDNS, result)
+	}
+
+	a, b := NewV4(), NewV4()
+	aHi, aLo := a.ToUint64Pair()
+	bHi, bLo := b.ToUint64Pair()
+	less := aHi < bHi || aHi == bHi && aLo < bLo
+	if less != a.Less(b) {
+		t.Errorf("uint64 pairs of %s and %s are not in UUID order", a, b)
+	}
+}
+
+func TestInt64Pair(t *testing.T) {
+	// the most and least significant bits of java.util.UUID
+	hi, lo := NamespaceDNS.ToInt64Pair()
+	if hi != 7757371264673321425 || lo != -9172705715073830712 {
+		t.Errorf("Failed to convert to int64 pair. Expected: "+
+			"7757371264673321425 -9172705715073830712, Received: %d %d",
+			hi, lo)
+	}
+	if result := FromInt64Pair(hi, lo); !result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to convert from int64 pair. Expected: %s, "+
+			"Received: %s", NamespaceDNS, result)
+	}
+	if hi, lo := Max.ToInt64Pair(); hi != -1 || lo != -1 ||
+		!FromInt64Pair(hi, lo).Equal(Max) {
+		t.Errorf("Failed to round trip max int64 pair: %d %d", hi, lo)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	// clockLeaseSlots is the number of clock sequence ranges leased to
+	// processes sharing a node ID.
+	clockLeaseSlots = 64
+	// clockLeaseMask selects the clock sequence within a leased range of
+	// 16384 / clockLeaseSlots values.
+	clockLeaseMask = 0x3FFF / clockLeaseSlots
+)
+
+// errLocked is returned by lockFile if another process holds the lock.
+var errLocked = errors.New("uuid: file is locked")
+
+// clockLease is a clock sequence range held by the process through a lock on
+// a lease file.
+type clockLease struct {
+	file *os.File
+	base uint16
+}
+
+// LeaseClockSequence confines the clock sequence of Version 1, 2 and 6 UUIDs
+// to a range that no other process on the host holds, so processes sharing
+// a hardware node ID cannot collide. The 14-bit clock sequence is split into
+// 64 ranges, each guarded by a lock on the file path.N, where N is the range.
+// The lock is held until the process exits or ReleaseClockSequence is
+// called, and is released by the operating system if the process crashes.
+// Returns an error if every range is held, or if file locks are not
+// supported on the platform.
+func LeaseClockSequence(path string) error {
+	return Default().LeaseClockSequence(path)
+}
+
+// LeaseClockSequence confines the clock sequence of time-based UUIDs
+// generated by g to a range no other process holds, see the package-level
+// LeaseClockSequence.
+func (g *Generator) LeaseClockSequence(path string) error {
+	g.ensureNode()
+	for slot := 0; slot < clockLeaseSlots; slot++ {
+		file, err := os.OpenFile(path+"."+strconv.Itoa(slot),
+			os.O_RDWR|os.O_CREATE, 0o600)
+		if err != nil {
+			return fmt.Errorf("uuid: open lease file: %w", err)
+		}
+		if err := lockFile(file); err != nil {
+			file.Close()
+			if errors.Is(err, errLocked) {
+				continue
+			}
+			return fmt.Errorf("uuid: lock lease file: %w", err)
+		}
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		g.releaseLease()
+		g.lease = &clockLease{file: file, base: uint16(slot) * (clockLeaseMask + 1)}
+		g.setClock(g.clock)
+		g.publishSequence()
+
+		return nil
+	}
+
+	return fmt.Errorf("uuid: all %d clock sequence ranges of %s are leased",
+		clockLeaseSlots, path)
+}
+
+// ReleaseClockSequence releases the range leased with LeaseClockSequence.
+func ReleaseClockSequence() {
+	Default().ReleaseClockSequence()
+}
+
+// ReleaseClockSequence releases the range leased by g. The clock sequence
+// stays in the range until it next changes.
+func (g *Generator) ReleaseClockSequence() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.releaseLease()
+}
+
+// releaseLease closes the lease file, releasing its lock. Must be called with
+// g.mu held.
+func (g *Generator) releaseLease() {
+	if g.lease != nil {
+		g.lease.file.Close()
+		g.lease = nil
+	}
+}
+
+// setClock sets the clock sequence, confined to the leased range if any. Must
+// be called with g.mu held.
+func (g *Generator) setClock(clock uint16) {
+	if g.lease != nil {
+		clock = g.lease.base | clock&clockLeaseMask
+	}
+	g.clock = clock
+}
@@ -0,0 +1,51 @@
+package uuid
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestLeaseClockSequence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uuid.lease")
+	clock := &tickClock{ticks: nanos100sAt(seededEpoch)}
+
+	first, second := NewGenerator(WithClock(clock)), NewGenerator(WithClock(clock))
+	err := first.LeaseClockSequence(path)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skip("file locks are not supported")
+	}
+	if err != nil {
+		t.Fatalf("failed to lease clock sequence: %v", err)
+	}
+	defer first.ReleaseClockSequence()
+	if err := second.LeaseClockSequence(path); err != nil {
+		t.Fatalf("failed to lease clock sequence: %v", err)
+	}
+	defer second.ReleaseClockSequence()
+
+	clock.ticks += 1000
+	a, b := first.NewV1(), second.NewV1()
+	if v1ClockSequence(a)/(clockLeaseMask+1) == v1ClockSequence(b)/(clockLeaseMask+1) {
+		t.Errorf("generators leased the same range: %d, %d",
+			v1ClockSequence(a), v1ClockSequence(b))
+	}
+
+	// clock sequence changes stay within the leased range
+	base := v1ClockSequence(a) &^ clockLeaseMask
+	for i := 0; i < 2*(clockLeaseMask+1); i++ {
+		clock.ticks--
+		if result := first.NewV1(); v1ClockSequence(result)&^clockLeaseMask != base {
+			t.Fatalf("clock sequence %d left the leased range %d",
+				v1ClockSequence(result), base)
+		}
+	}
+
+	// a released range can be leased again
+	second.ReleaseClockSequence()
+	third := NewGenerator()
+	if err := third.LeaseClockSequence(path); err != nil {
+		t.Fatalf("failed to lease released range: %v", err)
+	}
+	third.ReleaseClockSequence()
+}
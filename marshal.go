@@ -0,0 +1,181 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+var (
+	_ encoding.TextMarshaler     = UUID(nil)
+	_ encoding.TextUnmarshaler   = (*UUID)(nil)
+	_ encoding.BinaryMarshaler   = UUID(nil)
+	_ encoding.BinaryAppender    = UUID(nil)
+	_ encoding.BinaryUnmarshaler = (*UUID)(nil)
+	_ json.Marshaler             = UUID(nil)
+	_ json.Unmarshaler           = (*UUID)(nil)
+	_ gob.GobEncoder             = UUID(nil)
+	_ gob.GobDecoder             = (*UUID)(nil)
+)
+
+// jsonNull makes MarshalJSON encode the Nil UUID as null, see SetJSONNull.
+var jsonNull atomic.Bool
+
+// MarshalText returns the canonical form of uuid, implementing
+// encoding.TextMarshaler, so encoders such as encoding/json and encoding/xml
+// write UUIDs as strings. A nil UUID is written as the Nil UUID.
+func (uuid UUID) MarshalText() ([]byte, error) {
+	return uuid.AppendText(make([]byte, 0, 36))
+}
+
+// UnmarshalText parses text in any of the forms accepted by Parse,
+// implementing encoding.TextUnmarshaler.
+func (uuid *UUID) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*uuid = parsed
+
+	return nil
+}
+
+// MarshalBinary returns the 16 bytes of uuid, implementing
+// encoding.BinaryMarshaler. A nil UUID is written as the Nil UUID.
+func (uuid UUID) MarshalBinary() ([]byte, error) {
+	return uuid.AppendBinary(make([]byte, 0, 16))
+}
+
+// AppendBinary appends the 16 bytes of uuid to dst and returns the extended
+// buffer, implementing encoding.BinaryAppender.
+func (uuid UUID) AppendBinary(dst []byte) ([]byte, error) {
+	if uuid == nil {
+		uuid = Nil
+	}
+	if len(uuid) != 16 {
+		return dst, fmt.Errorf("uuid: invalid UUID length %d", len(uuid))
+	}
+
+	return append(dst, uuid...), nil
+}
+
+// Array returns the 16 bytes of uuid as an array, e.g. for map keys or for
+// APIs taking [16]byte values. A nil UUID returns the Nil UUID. Panics if
+// uuid is not 16 bytes long. To send UUIDs to Postgres in the binary format
+// with pgx, register them with the pgxuuid module instead.
+func (uuid UUID) Array() [16]byte {
+	if uuid == nil {
+		return [16]byte{}
+	}
+
+	return [16]byte(uuid)
+}
+
+// FromArray returns the UUID holding the 16 bytes of array.
+func FromArray(array [16]byte) UUID {
+	return UUID(array[:])
+}
+
+// UnmarshalBinary copies the 16 bytes of data into uuid, implementing
+// encoding.BinaryUnmarshaler. Returns an error if data is not 16 bytes long.
+func (uuid *UUID) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("uuid: invalid UUID length %d", len(data))
+	}
+	*uuid = append(UUID(nil), data...)
+
+	return nil
+}
+
+// SetJSONNull selects whether MarshalJSON encodes nil UUIDs and the Nil UUID
+// as JSON null instead of the string 00000000-0000-0000-0000-000000000000,
+// for APIs that use null for an absent identifier. Disabled by default.
+func SetJSONNull(enabled bool) {
+	jsonNull.Store(enabled)
+}
+
+// JSONNull reports whether MarshalJSON encodes the Nil UUID as JSON null.
+func JSONNull() bool {
+	return jsonNull.Load()
+}
+
+// MarshalJSON returns the canonical form of uuid as a JSON string,
+// implementing json.Marshaler. Nil UUIDs are encoded as null if SetJSONNull
+// is enabled.
+func (uuid UUID) MarshalJSON() ([]byte, error) {
+	if jsonNull.Load() && uuid.IsNil() {
+		return []byte("null"), nil
+	}
+
+	dst := make([]byte, 0, 38)
+	dst = append(dst, '"')
+	dst, err := uuid.AppendText(dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dst, '"'), nil
+}
+
+// UnmarshalJSON parses a JSON string in any of the forms accepted by Parse,
+// implementing json.Unmarshaler. The empty string decodes to a nil UUID and,
+// as is the convention for json.Unmarshaler, null leaves uuid unchanged.
+func (uuid *UUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' &&
+		bytes.IndexByte(data, '\\') < 0 {
+		s = string(data[1 : len(data)-1])
+	} else if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("uuid: invalid JSON UUID %s", data)
+	}
+	if s == "" {
+		*uuid = nil
+		return nil
+	}
+
+	return uuid.UnmarshalText([]byte(s))
+}
+
+// GobEncode returns the 16 bytes of uuid, implementing gob.GobEncoder.
+func (uuid UUID) GobEncode() ([]byte, error) {
+	return uuid.MarshalBinary()
+}
+
+// GobDecode copies the 16 bytes of data into uuid, implementing
+// gob.GobDecoder.
+func (uuid *UUID) GobDecode(data []byte) error {
+	return uuid.UnmarshalBinary(data)
+}
+
+// MarshalGQL writes the canonical form of uuid as a GraphQL string,
+// implementing the Marshaler interface of gqlgen, so schemas can declare a
+// UUID scalar backed by this type. A nil UUID is written as the Nil UUID.
+func (uuid UUID) MarshalGQL(w io.Writer) {
+	dst := make([]byte, 0, 38)
+	dst = append(dst, '"')
+	dst, _ = uuid.AppendText(dst)
+	w.Write(append(dst, '"'))
+}
+
+// UnmarshalGQL parses a GraphQL string in any of the forms accepted by Parse,
+// implementing the Unmarshaler interface of gqlgen.
+func (uuid *UUID) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("uuid: cannot unmarshal %T into UUID", v)
+	}
+
+	return uuid.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,205 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	expected := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	text, err := NamespaceDNS.MarshalText()
+	if err != nil || string(text) != expected {
+		t.Errorf("Failed to marshal text. Expected: %s, Received: %s",
+			expected, text)
+	}
+	if text, _ := UUID(nil).MarshalText(); string(text) != NilUUID {
+		t.Errorf("Failed to marshal a nil UUID. Expected: %s, Received: %s",
+			NilUUID, text)
+	}
+
+	var result UUID
+	err = result.UnmarshalText([]byte("{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}"))
+	if err != nil || !result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to unmarshal text. Expected: %s, Received: %s",
+			NamespaceDNS, result)
+	}
+	if err := result.UnmarshalText([]byte("not-a-uuid")); err == nil {
+		t.Errorf("unmarshaled an invalid UUID")
+	}
+}
+
+func TestMarshalTextXML(t *testing.T) {
+	type record struct {
+		ID   UUID `xml:"id"`
+		Attr UUID `xml:"attr,attr"`
+	}
+
+	in := record{ID: NewV4(), Attr: NamespaceURL}
+	data, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatalf("failed to marshal XML: %v", err)
+	}
+
+	var out record
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", data, err)
+	}
+	if !out.ID.Equal(in.ID) || !out.Attr.Equal(in.Attr) {
+		t.Errorf("Failed to round trip XML. Expected: %+v, Received: %+v",
+			in, out)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	in := NewV7()
+	data, err := in.MarshalBinary()
+	if err != nil || !bytes.Equal(data, in) {
+		t.Errorf("Failed to marshal binary. Expected: %x, Received: %x",
+			[]byte(in), data)
+	}
+	if data, _ := UUID(nil).MarshalBinary(); !bytes.Equal(data, Nil) {
+		t.Errorf("Failed to marshal a nil UUID. Expected: %x, Received: %x",
+			[]byte(Nil), data)
+	}
+	if _, err := (UUID{1, 2, 3}).MarshalBinary(); err == nil {
+		t.Errorf("marshaled a UUID of invalid length")
+	}
+
+	var out UUID
+	if err := out.UnmarshalBinary(data); err != nil || !out.Equal(in) {
+		t.Errorf("Failed to unmarshal binary. Expected: %s, Received: %s",
+			in, out)
+	}
+	// the result must not alias the input
+	data[0] ^= 0xFF
+	if !out.Equal(in) {
+		t.Errorf("unmarshaled UUID aliases its input")
+	}
+	if err := out.UnmarshalBinary(data[:15]); err == nil {
+		t.Errorf("unmarshaled 15 bytes")
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	type record struct {
+		ID     UUID `json:"id"`
+		Parent UUID `json:"parent"`
+	}
+
+	in := record{ID: NamespaceDNS}
+	data, err := json.Marshal(in)
+	expected := `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8",` +
+		`"parent":"00000000-0000-0000-0000-000000000000"}`
+	if err != nil || string(data) != expected {
+		t.Errorf("Failed to marshal JSON. Expected: %s, Received: %s",
+			expected, data)
+	}
+
+	SetJSONNull(true)
+	defer SetJSONNull(false)
+	data, err = json.Marshal(in)
+	expected = `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","parent":null}`
+	if err != nil || string(data) != expected {
+		t.Errorf("Failed to marshal Nil as null. Expected: %s, Received: %s",
+			expected, data)
+	}
+	if data, err := json.Marshal(UUID{0, 0}); err == nil {
+		t.Errorf("marshaled a 2 byte UUID as %s", data)
+	}
+
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil ||
+		!out.ID.Equal(NamespaceDNS) || out.Parent != nil {
+		t.Errorf("Failed to unmarshal JSON %s: %+v, %v", data, out, err)
+	}
+
+	for _, input := range []string{
+		`"6BA7B810-9DAD-11D1-80B4-00C04FD430C8"`,
+		`"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"6ba7b8109dad11d180b400c04fd430c8"`,
+		`"\u007b6ba7b810-9dad-11d1-80b4-00c04fd430c8}"`,
+	} {
+		var result UUID
+		if err := json.Unmarshal([]byte(input), &result); err != nil ||
+			!result.Equal(NamespaceDNS) {
+			t.Errorf("Failed to unmarshal %s. Expected: %s, Received: %s",
+				input, NamespaceDNS, result)
+		}
+	}
+
+	for _, input := range []string{`"nope"`, `12`, `{}`} {
+		var result UUID
+		if err := json.Unmarshal([]byte(input), &result); err == nil {
+			t.Errorf("unmarshaled invalid JSON UUID %s", input)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		ID  UUID
+		IDs []UUID
+	}
+
+	in := record{ID: NewV4(), IDs: []UUID{NewV7(), NamespaceOID}}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("failed to encode gob: %v", err)
+	}
+	// the value must be encoded as 16 raw bytes, not a 36 byte string
+	if bytes.Contains(buf.Bytes(), []byte(in.ID.String())) ||
+		!bytes.Contains(buf.Bytes(), in.ID) {
+		t.Errorf("UUID was not gob encoded as 16 bytes")
+	}
+
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("failed to decode gob: %v", err)
+	}
+	if !out.ID.Equal(in.ID) || len(out.IDs) != 2 ||
+		!out.IDs[0].Equal(in.IDs[0]) || !out.IDs[1].Equal(in.IDs[1]) {
+		t.Errorf("Failed to round trip gob. Expected: %+v, Received: %+v",
+			in, out)
+	}
+}
+
+func TestArray(t *testing.T) {
+	in := NewV4()
+	array := in.Array()
+	if !bytes.Equal(array[:], in) {
+		t.Errorf("Failed to convert to array. Expected: %x, Received: %x",
+			[]byte(in), array)
+	}
+	if UUID(nil).Array() != [16]byte{} {
+		t.Errorf("Failed to convert nil UUID to the Nil array")
+	}
+	if out := FromArray(array); !out.Equal(in) {
+		t.Errorf("Failed to convert from array. Expected: %s, Received: %s",
+			in, out)
+	}
+}
+
+func TestMarshalGQL(t *testing.T) {
+	var buf bytes.Buffer
+	NamespaceDNS.MarshalGQL(&buf)
+	expected := `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`
+	if buf.String() != expected {
+		t.Errorf("Failed to marshal GraphQL. Expected: %s, Received: %s",
+			expected, buf.String())
+	}
+
+	var result UUID
+	err := result.UnmarshalGQL("6BA7B810-9DAD-11D1-80B4-00C04FD430C8")
+	if err != nil || !result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to unmarshal GraphQL. Expected: %s, Received: %s",
+			NamespaceDNS, result)
+	}
+	for _, v := range []any{"nope", 42, nil} {
+		if err := result.UnmarshalGQL(v); err == nil {
+			t.Errorf("unmarshaled invalid GraphQL value %v", v)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// MessagePack formats, see
+// https://github.com/msgpack/msgpack/blob/master/spec.md.
+const (
+	msgpackNil    = 0xC0
+	msgpackBin8   = 0xC4
+	msgpackFixExt = 0xD8 // fixext 16
+	msgpackFixStr = 0xA0
+	msgpackStr8   = 0xD9
+)
+
+// msgpackExt holds the extension type written by MarshalMsgpack, or'ed with
+// msgpackExtFlag, or 0 to write bin 8, see SetMsgpackExt.
+var msgpackExt atomic.Int32
+
+const msgpackExtFlag = 1 << 8
+
+// SetMsgpackExt selects whether MarshalMsgpack writes UUIDs as a 16 byte
+// extension of application-defined type typ instead of a 16 byte binary, for
+// RPC systems that register a UUID extension type. Disabled by default.
+func SetMsgpackExt(enabled bool, typ int8) {
+	if !enabled {
+		msgpackExt.Store(0)
+		return
+	}
+	msgpackExt.Store(int32(uint8(typ)) | msgpackExtFlag)
+}
+
+// MarshalMsgpack returns uuid as a MessagePack bin 8 of 16 bytes, or a fixext
+// 16 if SetMsgpackExt is enabled, implementing the Marshaler interface of
+// MessagePack libraries such as vmihailenco/msgpack. A nil UUID is written
+// as nil.
+func (uuid UUID) MarshalMsgpack() ([]byte, error) {
+	if uuid == nil {
+		return []byte{msgpackNil}, nil
+	}
+	if len(uuid) != 16 {
+		return nil, fmt.Errorf("uuid: invalid UUID length %d", len(uuid))
+	}
+
+	data := make([]byte, 0, 18)
+	if ext := msgpackExt.Load(); ext != 0 {
+		data = append(data, msgpackFixExt, byte(ext))
+	} else {
+		data = append(data, msgpackBin8, 16)
+	}
+
+	return append(data, uuid...), nil
+}
+
+// UnmarshalMsgpack decodes a MessagePack bin 8 of 16 bytes, a fixext 16, a
+// string in any of the forms accepted by Parse, or nil, which decodes to a
+// nil UUID, implementing the Unmarshaler interface of MessagePack libraries
+// such as vmihailenco/msgpack. If SetMsgpackExt is enabled only extensions
+// of its type are accepted.
+func (uuid *UUID) UnmarshalMsgpack(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("uuid: invalid MessagePack UUID")
+	}
+
+	switch format := data[0]; {
+	case format == msgpackNil && len(data) == 1:
+		*uuid = nil
+		return nil
+	case format == msgpackBin8 && len(data) == 18 && data[1] == 16:
+		return uuid.UnmarshalBinary(data[2:])
+	case format == msgpackFixExt && len(data) == 18:
+		if ext := msgpackExt.Load(); ext != 0 && data[1] != byte(ext) {
+			return fmt.Errorf("uuid: invalid MessagePack extension type %d",
+				int8(data[1]))
+		}
+		return uuid.UnmarshalBinary(data[2:])
+	case format&0xE0 == msgpackFixStr && len(data) == int(format&0x1F)+1:
+		return uuid.UnmarshalText(data[1:])
+	case format == msgpackStr8 && len(data) >= 2 && len(data) == int(data[1])+2:
+		return uuid.UnmarshalText(data[2:])
+	}
+
+	return fmt.Errorf("uuid: cannot decode MessagePack format %#x into UUID",
+		data[0])
+}
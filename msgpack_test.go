@@ -0,0 +1,73 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMarshalMsgpack(t *testing.T) {
+	data, err := NamespaceDNS.MarshalMsgpack()
+	expected := append([]byte{0xC4, 0x10}, NamespaceDNS...)
+	if err != nil || !bytes.Equal(data, expected) {
+		t.Errorf("Failed to marshal MessagePack. Expected: %x, Received: %x",
+			expected, data)
+	}
+	if data, _ := UUID(nil).MarshalMsgpack(); !bytes.Equal(data, []byte{0xC0}) {
+		t.Errorf("Failed to marshal nil UUID as nil. Received: %x", data)
+	}
+
+	SetMsgpackExt(true, -2)
+	defer SetMsgpackExt(false, 0)
+	ext, _ := NamespaceDNS.MarshalMsgpack()
+	expected = append([]byte{0xD8, 0xFE}, NamespaceDNS...)
+	if !bytes.Equal(ext, expected) {
+		t.Errorf("Failed to marshal MessagePack extension. Expected: %x, "+
+			"Received: %x", expected, ext)
+	}
+
+	var result UUID
+	if err := result.UnmarshalMsgpack(ext); err != nil ||
+		!result.Equal(NamespaceDNS) {
+		t.Errorf("Failed to unmarshal MessagePack extension. Expected: %s, "+
+			"Received: %s", NamespaceDNS, result)
+	}
+	ext[1] = 0x05
+	if err := result.UnmarshalMsgpack(ext); err == nil {
+		t.Errorf("unmarshaled a MessagePack extension of another type")
+	}
+}
+
+func TestUnmarshalMsgpack(t *testing.T) {
+	text := NamespaceDNS.String()
+	hex := NamespaceDNS.FormatAs(FormatHex)
+	for _, input := range [][]byte{
+		append([]byte{0xC4, 0x10}, NamespaceDNS...),
+		append([]byte{0xD8, 0x01}, NamespaceDNS...),
+		append([]byte{0xD9, byte(len(text))}, text...),
+		append([]byte{0xD9, byte(len(hex))}, hex...),
+	} {
+		var result UUID
+		if err := result.UnmarshalMsgpack(input); err != nil ||
+			!result.Equal(NamespaceDNS) {
+			t.Errorf("Failed to unmarshal MessagePack %x. Expected: %s, "+
+				"Received: %s", input, NamespaceDNS, result)
+		}
+	}
+
+	result := NewV4()
+	if err := result.UnmarshalMsgpack([]byte{0xC0}); err != nil || result != nil {
+		t.Errorf("Failed to unmarshal MessagePack nil. Received: %s", result)
+	}
+
+	for _, input := range [][]byte{
+		nil,
+		append([]byte{0xC4, 0x0F}, NamespaceDNS[:15]...),
+		append([]byte{0xD9, 0x24}, text[:35]...),
+		append([]byte{0xA3}, "abc"...),
+		{0x01},
+	} {
+		if err := result.UnmarshalMsgpack(input); err == nil {
+			t.Errorf("unmarshaled invalid MessagePack %x", input)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+	"io"
+)
+
+// Option configures a call to New, NewUUIDReader or NewGenerator. Options that
+// do not apply to a call are ignored.
+type Option func(*options)
+
+type options struct {
+	namespace UUID
+	name      string
+	hasName   bool
+	domain    Domain
+	id        uint32
+	hasDomain bool
+	text      bool
+
+	// generator configuration, see NewGenerator
+	nodeID          []byte
+	interfacePolicy *InterfacePolicy
+	hostnameSalt    *string
+	nodeChain       []NodeStrategy
+	clock           Clock
+	rand            io.Reader
+	backend         Backend
+	privacyNode     bool
+	v7Monotonic     bool
+}
+
+// WithNamespace sets the namespace UUID used by the name-based versions. For
+// NewGenerator it sets the default namespace of the Generator, see
+// SetNamespace, and for New it defaults to that namespace.
+func WithNamespace(namespaceUUID UUID) Option {
+	return func(o *options) {
+		o.namespace = namespaceUUID
+	}
+}
+
+// WithName sets the name hashed by the name-based versions. Required for
+// Version3, Version5 and Version8, which New generates with SHA-256.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+		o.hasName = true
+	}
+}
+
+// WithDomain sets the local domain and identifier embedded by Version2.
+// Required for Version2.
+func WithDomain(domain Domain, id uint32) Option {
+	return func(o *options) {
+		o.domain = domain
+		o.id = id
+		o.hasDomain = true
+	}
+}
+
+// WithText makes the reader returned by NewUUIDReader emit canonical UUID
+// strings, one per line, instead of raw 16 byte UUIDs. It has no effect on
+// New.
+func WithText() Option {
+	return func(o *options) {
+		o.text = true
+	}
+}
+
+// WithNodeID sets the node ID embedded in time-based UUIDs by a Generator
+// created with NewGenerator, instead of the hardware address of a network
+// interface. It has no effect on New.
+func WithNodeID(node [6]byte) Option {
+	return func(o *options) {
+		o.nodeID = node[:]
+	}
+}
+
+// WithHostnameNode makes a Generator created with NewGenerator use the node ID
+// returned by HostnameNodeID for salt. If the hostname is unavailable the
+// node ID is selected as if the option was not given. It has no effect on
+// New.
+func WithHostnameNode(salt string) Option {
+	return func(o *options) {
+		o.hostnameSalt = &salt
+	}
+}
+
+// WithNodeChain sets the strategies tried in order to acquire the node ID of a
+// Generator created with NewGenerator. A random node ID is used if none
+// succeeds. Defaults to DefaultNodeChain. It has no effect on New.
+func WithNodeChain(chain ...NodeStrategy) Option {
+	return func(o *options) {
+		o.nodeChain = chain
+	}
+}
+
+// WithInterfacePolicy sets the policy selecting the network interface whose
+// hardware address is the node ID of a Generator created with NewGenerator,
+// in the default node chain. Defaults to DefaultInterfacePolicy. It has no
+// effect on New.
+func WithInterfacePolicy(policy InterfacePolicy) Option {
+	return func(o *options) {
+		o.interfacePolicy = &policy
+	}
+}
+
+// WithClock sets the Clock of a Generator created with NewGenerator. Defaults
+// to a MonotonicClock created when the package was loaded. It has no effect
+// on New.
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithRand sets the source of random bits of a Generator created with
+// NewGenerator. Defaults to crypto/rand. It has no effect on New.
+func WithRand(r io.Reader) Option {
+	return func(o *options) {
+		o.rand = r
+	}
+}
+
+// WithBackend sets the Backend generating the Version 4 UUIDs of a Generator
+// created with NewGenerator, e.g. NewKernelBackend or NewCoCreateGuidBackend,
+// see SetBackend. It has no effect on New.
+func WithBackend(backend Backend) Option {
+	return func(o *options) {
+		o.backend = backend
+	}
+}
+
+// WithPrivacyNode makes a Generator created with NewGenerator embed a random
+// node ID in time-based UUIDs, see SetPrivacyNode. It has no effect on New.
+func WithPrivacyNode() Option {
+	return func(o *options) {
+		o.privacyNode = true
+	}
+}
+
+// WithV7Monotonic makes a Generator created with NewGenerator order its
+// Version 7 UUIDs with those of every other Generator in process-wide
+// monotonic mode, see SetV7Monotonic. It has no effect on New.
+func WithV7Monotonic() Option {
+	return func(o *options) {
+		o.v7Monotonic = true
+	}
+}
+
+// New generates a UUID of the given version, for callers that select the
+// version at run time, e.g. from configuration. Returns an error if the
+// version is unknown, a required option is missing or the random source
+// fails.
+func New(version Version, opts ...Option) (UUID, error) {
+	return Default().New(version, opts...)
+}
+
+// New generates a UUID of the given version using the state of g, see the
+// package-level New.
+func (g *Generator) New(version Version, opts ...Option) (UUID, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	switch version {
+	case Version1:
+		return g.NewV1E()
+	case Version2:
+		if !o.hasDomain {
+			return nil, fmt.Errorf("uuid: %s requires WithDomain", version)
+		}
+		return g.NewV2E(o.domain, o.id)
+	case Version4:
+		return g.NewV4E()
+	case Version6:
+		return g.NewV6E()
+	case Version7:
+		return g.NewV7E()
+	case Version3, Version5, Version8:
+		if !o.hasName {
+			return nil, fmt.Errorf("uuid: %s requires WithName", version)
+		}
+		if o.namespace == nil {
+			o.namespace = g.Namespace()
+		}
+		var result UUID
+		var err error
+		switch version {
+		case Version3:
+			result, err = NewV3E(o.namespace, o.name)
+		case Version5:
+			result = NewV5(o.namespace, o.name)
+		default:
+			result = NewV8SHA256(o.namespace, o.name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		g.countGenerated(version, 1)
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("uuid: unknown version %d", version)
+}
+
+// Must returns uuid if err is nil and panics otherwise. It is intended to wrap
+// the error-returning constructors and Parse in variable initializations,
+// e.g. uuid.Must(uuid.NewV4E()).
+func Must(uuid UUID, err error) UUID {
+	if err != nil {
+		panic(err)
+	}
+
+	return uuid
+}
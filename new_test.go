@@ -0,0 +1,48 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for version := Version1; version <= Version8; version++ {
+		result, err := New(version, WithName("test"), WithDomain(DomainPerson, 501))
+		if err != nil {
+			t.Errorf("failed to generate %s: %v", version, err)
+			continue
+		}
+		if result.Version() != version {
+			t.Errorf("incorrect version detected. Expected: %s, Received: %s",
+				version, result.Version())
+		}
+	}
+
+	namespace := NewV4()
+	result, _ := New(Version5, WithNamespace(namespace), WithName("test"))
+	if !result.Equal(NewV5(namespace, "test")) {
+		t.Errorf("failed to apply namespace option")
+	}
+
+	if _, err := New(Version5); err == nil {
+		t.Errorf("generated a name-based UUID without a name")
+	}
+	if _, err := New(Version2); err == nil {
+		t.Errorf("generated a DCE Security UUID without a domain")
+	}
+	if _, err := New(Version(9)); err == nil {
+		t.Errorf("generated a UUID of an unknown version")
+	}
+}
+
+func TestMust(t *testing.T) {
+	if result := Must(NewV4E()); result.Version() != Version4 {
+		t.Errorf("incorrect version detected: %s", result.Version())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("failed to panic on error")
+		}
+	}()
+	Must(Parse("not a uuid"))
+}
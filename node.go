@@ -0,0 +1,168 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/sha256"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// InterfacePolicy selects the network interface whose hardware address
+// becomes the node ID of time-based UUIDs. The zero value selects the first
+// interface with a six-byte address.
+type InterfacePolicy struct {
+	// Names, if not empty, restricts selection to the interfaces with these
+	// names, in order of preference.
+	Names []string
+	// SkipLocal skips locally administered and multicast addresses, such as
+	// those assigned to docker and other virtual interfaces.
+	SkipLocal bool
+	// PreferPhysical selects a physical interface before any interface that
+	// looks virtual, e.g. bridges, tunnels and veth pairs.
+	PreferPhysical bool
+}
+
+// DefaultInterfacePolicy is the InterfacePolicy used unless
+// WithInterfacePolicy is given.
+var DefaultInterfacePolicy = InterfacePolicy{SkipLocal: true, PreferPhysical: true}
+
+// virtualInterfacePrefixes are the name prefixes of common virtual
+// interfaces.
+var virtualInterfacePrefixes = []string{"docker", "br-", "veth", "virbr",
+	"vmnet", "vboxnet", "tun", "tap", "cni", "flannel", "cali", "weave",
+	"lxc", "lxd", "utun", "bridge", "awdl", "llw", "zt"}
+
+// Select returns the hardware address of the interface chosen by the policy
+// from interfaces, and false if none qualifies.
+func (p InterfacePolicy) Select(interfaces []net.Interface) (net.HardwareAddr, bool) {
+	candidates := interfaces
+	if len(p.Names) > 0 {
+		candidates = candidates[:0:0]
+		for _, name := range p.Names {
+			for _, inter := range interfaces {
+				if inter.Name == name {
+					candidates = append(candidates, inter)
+				}
+			}
+		}
+	}
+
+	var fallback net.HardwareAddr
+	for _, inter := range candidates {
+		if len(inter.HardwareAddr) != 6 {
+			continue
+		}
+		// the locally administered and multicast bits of the first octet
+		if p.SkipLocal && inter.HardwareAddr[0]&0x03 != 0 {
+			continue
+		}
+		if p.PreferPhysical && !isPhysicalInterface(inter) {
+			if fallback == nil {
+				fallback = inter.HardwareAddr
+			}
+			continue
+		}
+
+		return inter.HardwareAddr, true
+	}
+
+	return fallback, fallback != nil
+}
+
+// isPhysicalInterface reports whether inter looks like a physical network
+// interface. On Linux only interfaces backed by a device in sysfs qualify,
+// elsewhere the name is compared with common virtual interface names.
+func isPhysicalInterface(inter net.Interface) bool {
+	if inter.Flags&net.FlagLoopback != 0 {
+		return false
+	}
+	for _, prefix := range virtualInterfacePrefixes {
+		if strings.HasPrefix(inter.Name, prefix) {
+			return false
+		}
+	}
+	if runtime.GOOS == "linux" {
+		_, err := os.Stat("/sys/class/net/" + inter.Name + "/device")
+		return err == nil
+	}
+
+	return true
+}
+
+// SetInterfacePolicy selects the node ID of Version 1, 2 and 6 UUIDs again,
+// using policy. If no interface qualifies a random node ID is used. In privacy
+// mode the new node ID takes effect once privacy mode is disabled.
+func SetInterfacePolicy(policy InterfacePolicy) {
+	Default().SetInterfacePolicy(policy)
+}
+
+// SetInterfacePolicy selects the node ID of time-based UUIDs generated by g
+// again, using policy.
+func (g *Generator) SetInterfacePolicy(policy InterfacePolicy) {
+	if g.acquireNodeOnce([]NodeStrategy{InterfaceNode(policy)}) {
+		return
+	}
+	node, source, err := g.interfaceNode(policy)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.baseNode, g.baseNodeSource, g.baseNodeErr = node, source, err
+	if g.nodeSource != NodePrivacy {
+		g.node, g.nodeSource, g.nodeErr = g.baseNode, g.baseNodeSource, err
+		g.publishSequence()
+	}
+}
+
+// interfaceNode returns the hardware address of the network interface chosen
+// by policy, or a random node ID and the error of the random source if there
+// is none.
+func (g *Generator) interfaceNode(policy InterfacePolicy) ([]byte, NodeSource, error) {
+	// read network interfaces, on error there are none to select from
+	interfaces, _ := net.Interfaces()
+
+	if addr, ok := policy.Select(interfaces); ok {
+		return addr, NodeHardware, nil
+	}
+
+	// if unable to find a six-byte interface, set to random
+	node, err := g.randomNode()
+	return node, NodeRandom, err
+}
+
+// HostnameNodeID returns a node ID derived from the SHA-256 hash of the
+// hostname and salt, with the multicast and locally administered bits set so
+// it cannot conflict with the address of a network interface. It gives a
+// stable node ID per host on platforms, such as containers and serverless
+// runtimes, where hardware addresses are missing or meaningless. Use it with
+// SetNodeID or WithHostnameNode.
+func HostnameNodeID(salt string) ([6]byte, error) {
+	hostname, err := osHostname()
+	if err != nil {
+		return [6]byte{}, err
+	}
+
+	return hashNodeID(hostname, salt), nil
+}
+
+// osHostname is os.Hostname, replaced by tests.
+var osHostname = os.Hostname
+
+// hashNodeID derives a node ID from hostname and salt.
+func hashNodeID(hostname, salt string) [6]byte {
+	hash := sha256.New()
+	hash.Write([]byte(salt))
+	hash.Write([]byte{0})
+	hash.Write([]byte(hostname))
+
+	var node [6]byte
+	copy(node[:], hash.Sum(nil))
+	node[0] |= 0x03
+
+	return node
+}
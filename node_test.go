@@ -0,0 +1,126 @@
+package uuid
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestInterfacePolicy(t *testing.T) {
+	docker := net.Interface{Name: "docker0",
+		HardwareAddr: net.HardwareAddr{0x02, 0x42, 0xAC, 0x11, 0x00, 0x02}}
+	bridge := net.Interface{Name: "br-lan",
+		HardwareAddr: net.HardwareAddr{0x00, 0x16, 0x3E, 0x00, 0x00, 0x01}}
+	eth := net.Interface{Name: "eth0",
+		HardwareAddr: net.HardwareAddr{0x00, 0x1A, 0x2B, 0x3C, 0x4D, 0x5E}}
+	wifi := net.Interface{Name: "wlan0",
+		HardwareAddr: net.HardwareAddr{0x00, 0x1A, 0x2B, 0x3C, 0x4D, 0x5F}}
+	loopback := net.Interface{Name: "lo", Flags: net.FlagLoopback}
+	interfaces := []net.Interface{loopback, docker, bridge, eth, wifi}
+
+	tests := []struct {
+		policy   InterfacePolicy
+		expected net.HardwareAddr
+	}{
+		{InterfacePolicy{}, docker.HardwareAddr},
+		{InterfacePolicy{SkipLocal: true}, bridge.HardwareAddr},
+		{InterfacePolicy{Names: []string{"wlan0", "eth0"}}, wifi.HardwareAddr},
+		{InterfacePolicy{Names: []string{"docker0"}, SkipLocal: true}, nil},
+	}
+	for _, test := range tests {
+		result, ok := test.policy.Select(interfaces)
+		if ok != (test.expected != nil) || !bytes.Equal(result, test.expected) {
+			t.Errorf("Failed to select interface with %+v. Expected: %s, "+
+				"Received: %s", test.policy, test.expected, result)
+		}
+	}
+
+	// virtual interfaces are only used when no physical interface qualifies
+	result, ok := InterfacePolicy{PreferPhysical: true}.Select(
+		[]net.Interface{docker, bridge})
+	if !ok || !bytes.Equal(result, docker.HardwareAddr) {
+		t.Errorf("Failed to fall back to virtual interface. Expected: %s, "+
+			"Received: %s", docker.HardwareAddr, result)
+	}
+}
+
+func TestIsPhysicalInterface(t *testing.T) {
+	for _, name := range []string{"docker0", "veth1a2b3c", "br-0123", "virbr0"} {
+		if isPhysicalInterface(net.Interface{Name: name}) {
+			t.Errorf("reported virtual interface %s as physical", name)
+		}
+	}
+	if isPhysicalInterface(net.Interface{Name: "lo", Flags: net.FlagLoopback}) {
+		t.Errorf("reported loopback interface as physical")
+	}
+}
+
+func TestHostnameNodeID(t *testing.T) {
+	defer func(hostname func() (string, error)) { osHostname = hostname }(osHostname)
+	osHostname = func() (string, error) { return "web-1", nil }
+
+	first, err := HostnameNodeID("salt")
+	if err != nil {
+		t.Fatalf("failed to derive node ID: %v", err)
+	}
+	second, _ := HostnameNodeID("salt")
+	if first != second {
+		t.Errorf("Failed to derive a stable node ID. Expected: %x, "+
+			"Received: %x", first, second)
+	}
+	// check the multicast and locally administered bits are set
+	if first[0]&0x03 != 0x03 {
+		t.Errorf("hostname node without local and multicast bits: %x", first)
+	}
+	if other, _ := HostnameNodeID("pepper"); other == first {
+		t.Errorf("different salts produced the same node ID: %x", first)
+	}
+
+	g := NewGenerator(WithHostnameNode("salt"))
+	if g.NodeID() != first || g.Health().NodeSource != NodeHostname {
+		t.Errorf("Failed to use hostname node. Expected: %x, Received: %x",
+			first, g.NodeID())
+	}
+}
+
+// failingNode is a NodeStrategy that never succeeds.
+type failingNode struct{}
+
+func (failingNode) Source() NodeSource {
+	return NodeStatic
+}
+
+func (failingNode) Node() ([6]byte, bool) {
+	return [6]byte{}, false
+}
+
+func TestNodeChain(t *testing.T) {
+	t.Setenv(NodeEnvVar, "02:00:5e:10:00:01")
+	env := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}
+	static := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x02}
+
+	g := NewGenerator()
+	if g.NodeID() != env || g.Health().NodeSource != NodeEnv {
+		t.Errorf("Failed to read node from environment. Expected: %x, "+
+			"Received: %x", env, g.NodeID())
+	}
+
+	g = NewGenerator(WithNodeChain(failingNode{}, StaticNode(static)))
+	if g.NodeID() != static || g.Health().NodeSource != NodeStatic {
+		t.Errorf("Failed to fall back to the next strategy. Expected: %x, "+
+			"Received: %x", static, g.NodeID())
+	}
+
+	g.SetNodeChain(failingNode{})
+	if g.Health().NodeSource != NodeRandom || g.NodeID()[0]&0x01 != 1 {
+		t.Errorf("Failed to fall back to a random node: %x", g.NodeID())
+	}
+
+	t.Setenv(NodeEnvVar, "not a mac")
+	if _, ok := EnvNode(NodeEnvVar).Node(); ok {
+		t.Errorf("parsed an invalid node ID from the environment")
+	}
+	if len(DefaultNodeChain()) != 4 {
+		t.Errorf("incorrect default node chain: %v", DefaultNodeChain())
+	}
+}
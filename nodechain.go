@@ -0,0 +1,184 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	crand "crypto/rand"
+	"net"
+	"os"
+)
+
+// NodeEnvVar is the environment variable read by the default node chain,
+// holding a node ID as a MAC address, e.g. 02:00:5e:10:00:01.
+const NodeEnvVar = "UUID_NODE_ID"
+
+// NodeStrategy is one way of acquiring the node ID of time-based UUIDs.
+// Strategies are tried in order by a node chain, see WithNodeChain, until
+// one succeeds.
+type NodeStrategy interface {
+	// Source reports how the strategy acquires the node ID, reported by
+	// Health when it is selected.
+	Source() NodeSource
+	// Node returns the node ID, or false if it cannot be acquired.
+	Node() ([6]byte, bool)
+}
+
+// DefaultNodeChain returns the node chain used unless WithNodeChain is given:
+// the environment variable NodeEnvVar, the network interface chosen by
+// DefaultInterfacePolicy, the hashed hostname and finally a random node ID.
+func DefaultNodeChain() []NodeStrategy {
+	return defaultNodeChain(DefaultInterfacePolicy)
+}
+
+func defaultNodeChain(policy InterfacePolicy) []NodeStrategy {
+	return []NodeStrategy{EnvNode(NodeEnvVar), InterfaceNode(policy),
+		HostnameNode(""), RandomNode()}
+}
+
+// StaticNode returns a NodeStrategy always returning node.
+func StaticNode(node [6]byte) NodeStrategy {
+	return staticNode(node)
+}
+
+type staticNode [6]byte
+
+func (s staticNode) Source() NodeSource {
+	return NodeStatic
+}
+
+func (s staticNode) Node() ([6]byte, bool) {
+	return s, true
+}
+
+// EnvNode returns a NodeStrategy parsing the node ID from the environment
+// variable name as a MAC address. It fails if the variable is unset or is not
+// a six-byte address.
+func EnvNode(name string) NodeStrategy {
+	return envNode(name)
+}
+
+type envNode string
+
+func (e envNode) Source() NodeSource {
+	return NodeEnv
+}
+
+func (e envNode) Node() ([6]byte, bool) {
+	var node [6]byte
+
+	addr, err := net.ParseMAC(os.Getenv(string(e)))
+	if err != nil || len(addr) != 6 {
+		return node, false
+	}
+	copy(node[:], addr)
+
+	return node, true
+}
+
+// InterfaceNode returns a NodeStrategy returning the hardware address of the
+// network interface chosen by policy.
+func InterfaceNode(policy InterfacePolicy) NodeStrategy {
+	return nicNode{policy}
+}
+
+type nicNode struct {
+	policy InterfacePolicy
+}
+
+func (i nicNode) Source() NodeSource {
+	return NodeHardware
+}
+
+func (i nicNode) Node() ([6]byte, bool) {
+	var node [6]byte
+
+	// read network interfaces, on error there are none to select from
+	interfaces, _ := net.Interfaces()
+	addr, ok := i.policy.Select(interfaces)
+	copy(node[:], addr)
+
+	return node, ok
+}
+
+// HostnameNode returns a NodeStrategy returning HostnameNodeID for salt.
+func HostnameNode(salt string) NodeStrategy {
+	return hostnameNode(salt)
+}
+
+type hostnameNode string
+
+func (h hostnameNode) Source() NodeSource {
+	return NodeHostname
+}
+
+func (h hostnameNode) Node() ([6]byte, bool) {
+	node, err := HostnameNodeID(string(h))
+	return node, err == nil
+}
+
+// RandomNode returns a NodeStrategy returning a random node ID with the
+// multicast bit set. In a node chain the random source of the Generator is
+// used, so it always succeeds.
+func RandomNode() NodeStrategy {
+	return randomNode{}
+}
+
+type randomNode struct{}
+
+func (randomNode) Source() NodeSource {
+	return NodeRandom
+}
+
+func (randomNode) Node() ([6]byte, bool) {
+	var node [6]byte
+	if _, err := crand.Read(node[:]); err != nil {
+		return node, false
+	}
+	node[0] |= 0x01
+
+	return node, true
+}
+
+// SetNodeChain selects the node ID of Version 1, 2 and 6 UUIDs again, using
+// the first strategy of chain that succeeds, or a random node ID if none
+// does. In privacy mode the new node ID takes effect once privacy mode is
+// disabled.
+func SetNodeChain(chain ...NodeStrategy) {
+	Default().SetNodeChain(chain...)
+}
+
+// SetNodeChain selects the node ID of time-based UUIDs generated by g again,
+// see the package-level SetNodeChain.
+func (g *Generator) SetNodeChain(chain ...NodeStrategy) {
+	if g.acquireNodeOnce(chain) {
+		return
+	}
+	node, source, err := g.chainNode(chain)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.baseNode, g.baseNodeSource, g.baseNodeErr = node, source, err
+	if g.nodeSource != NodePrivacy {
+		g.node, g.nodeSource, g.nodeErr = g.baseNode, g.baseNodeSource, err
+		g.publishSequence()
+	}
+}
+
+// chainNode returns the node ID of the first strategy of chain that succeeds,
+// or a random node ID and the error of the random source if none does.
+func (g *Generator) chainNode(chain []NodeStrategy) ([]byte, NodeSource, error) {
+	for _, strategy := range chain {
+		if _, ok := strategy.(randomNode); ok {
+			break
+		}
+		if node, ok := strategy.Node(); ok {
+			return node[:], strategy.Source(), nil
+		}
+	}
+
+	node, err := g.randomNode()
+	return node, NodeRandom, err
+}
@@ -0,0 +1,151 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+var _ flag.Value = (*UUID)(nil)
+
+// canonicalOffsets holds the string offset of each byte's pair of hex digits
+// in the canonical xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form.
+var canonicalOffsets = [16]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28,
+	30, 32, 34}
+
+// Parse converts a UUID string into a UUID. Parse accepts the canonical 36
+// character form, the 32 character hex form without dashes, the canonical
+// form prefixed with urn:uuid: and the canonical form wrapped in braces as
+// used by Windows GUIDs, in upper or lower case. The version and variant bits
+// are not checked, see ParseStrict.
+func Parse(s string) (UUID, error) {
+	uuid := make(UUID, 16)
+	if !decode(uuid, s) {
+		return nil, fmt.Errorf("uuid: invalid UUID format %q", s)
+	}
+
+	return uuid, nil
+}
+
+// ParseStrict converts a UUID string into a UUID like Parse, but rejects
+// strings whose version is not 1 through 8 or whose variant is not RFC 4122.
+func ParseStrict(s string) (UUID, error) {
+	uuid, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if version := uuid.Version(); version < Version1 || version > Version8 {
+		return nil, fmt.Errorf("uuid: invalid version %d in %q", version, s)
+	}
+
+	if uuid.Variant() != VariantRFC4122 {
+		return nil, fmt.Errorf("uuid: invalid variant in %q", s)
+	}
+
+	return uuid, nil
+}
+
+// Set parses s in any of the forms accepted by Parse into uuid, implementing
+// flag.Value together with String, so UUIDs can be command-line flags:
+//
+//	namespace := uuid.NamespaceDNS
+//	flag.Var(&namespace, "namespace", "namespace of the generated UUIDs")
+func (uuid *UUID) Set(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*uuid = parsed
+
+	return nil
+}
+
+// Normalize converts any UUID string accepted by Parse into the lowercase
+// canonical 36 character form.
+func Normalize(s string) (string, error) {
+	uuid, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+
+	return uuid.String(), nil
+}
+
+// IsValid reports whether s is a well formed UUID string in any of the forms
+// accepted by Parse. IsValid does not allocate.
+func IsValid(s string) bool {
+	return decode(nil, s)
+}
+
+// decode writes the 16 bytes represented by s into dst and reports whether s
+// is well formed. If dst is nil, s is only validated.
+func decode(dst []byte, s string) bool {
+	switch {
+	case len(s) == 45 && strings.EqualFold(s[:9], urnPrefix):
+		s = s[9:]
+	case len(s) == 38 && s[0] == '{' && s[37] == '}':
+		s = s[1:37]
+	}
+
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return false
+		}
+		for i, offset := range canonicalOffsets {
+			b, ok := hexToByte(s[offset], s[offset+1])
+			if !ok {
+				return false
+			}
+			if dst != nil {
+				dst[i] = b
+			}
+		}
+	case 32:
+		for i := 0; i < 16; i++ {
+			b, ok := hexToByte(s[i*2], s[i*2+1])
+			if !ok {
+				return false
+			}
+			if dst != nil {
+				dst[i] = b
+			}
+		}
+	default:
+		return false
+	}
+
+	return true
+}
+
+// hexToByte converts a pair of hex digits into a byte.
+func hexToByte(hi, lo byte) (byte, bool) {
+	h, ok := hexToNibble(hi)
+	if !ok {
+		return 0, false
+	}
+	l, ok := hexToNibble(lo)
+	if !ok {
+		return 0, false
+	}
+
+	return h<<4 | l, true
+}
+
+func hexToNibble(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,130 @@
+package uuid
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	expected := NewV4()
+
+	result, err := Parse(PrintUUID(expected))
+	if err != nil {
+		t.Fatalf("failed to parse canonical UUID: %v", err)
+	}
+	if !bytes.Equal(result, expected) {
+		t.Errorf("Failed to parse canonical UUID. Expected: %s, "+
+			"Received: %s", PrintUUID(expected), PrintUUID(result))
+	}
+
+	// the dash-free and uppercase forms should decode to the same bytes
+	result, err = Parse("6BA7B8109DAD11D180B400C04FD430C8")
+	if err != nil {
+		t.Fatalf("failed to parse hex UUID: %v", err)
+	}
+	if PrintUUID(result) != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("Failed to parse hex UUID. Received: %s",
+			PrintUUID(result))
+	}
+
+	invalid := []string{
+		"",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c",
+		"6ba7b810-9dad-11d1-80b4_00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cg",
+	}
+	for _, s := range invalid {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("parsed invalid UUID string %q", s)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	if _, err := ParseStrict(PrintUUID(NewV1())); err != nil {
+		t.Errorf("rejected a valid version 1 UUID: %v", err)
+	}
+
+	// version nibble of 0
+	if _, err := ParseStrict("6ba7b810-9dad-01d1-80b4-00c04fd430c8"); err == nil {
+		t.Errorf("accepted a UUID with an invalid version")
+	}
+
+	// variant bits of 11 (reserved for Microsoft)
+	if _, err := ParseStrict("6ba7b810-9dad-11d1-c0b4-00c04fd430c8"); err == nil {
+		t.Errorf("accepted a UUID with an invalid variant")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	expected := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	inputs := []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}",
+	}
+	for _, s := range inputs {
+		result, err := Normalize(s)
+		if err != nil {
+			t.Errorf("failed to normalize %q: %v", s, err)
+			continue
+		}
+		if result != expected {
+			t.Errorf("Failed to normalize %q. Expected: %s, Received: %s",
+				s, expected, result)
+		}
+	}
+
+	if _, err := Normalize("not a uuid"); err == nil {
+		t.Errorf("normalized an invalid UUID string")
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	valid := PrintUUID(NewV4())
+	if !IsValid(valid) {
+		t.Errorf("rejected a valid UUID string %q", valid)
+	}
+	if IsValid("6ba7b810-9dad-11d1-80b4-00c04fd430cg") {
+		t.Errorf("accepted an invalid UUID string")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		IsValid(valid)
+		IsValid("not a uuid")
+	})
+	if allocs != 0 {
+		t.Errorf("IsValid allocated %v times", allocs)
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	s := PrintUUID(NewV4())
+	for i := 0; i < b.N; i++ {
+		IsValid(s)
+	}
+}
+
+func TestSet(t *testing.T) {
+	namespace := NamespaceDNS
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.Var(&namespace, "namespace", "namespace UUID")
+
+	err := flags.Parse([]string{"-namespace=6ba7b811-9dad-11d1-80b4-00c04fd430c8"})
+	if err != nil || !namespace.Equal(NamespaceURL) {
+		t.Errorf("Failed to set flag. Expected: %s, Received: %s",
+			NamespaceURL, namespace)
+	}
+	if !NamespaceDNS.Equal(UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11,
+		0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}) {
+		t.Errorf("setting a flag modified its default value")
+	}
+	if err := flags.Parse([]string{"-namespace=nope"}); err == nil {
+		t.Errorf("accepted an invalid UUID flag")
+	}
+}
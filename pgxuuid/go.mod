@@ -0,0 +1,10 @@
+module github.com/edwardfward/gouuid/pgxuuid
+
+go 1.25.0
+
+require (
+	github.com/edwardfward/gouuid v0.0.0
+	github.com/jackc/pgx/v5 v5.11.0
+)
+
+replace github.com/edwardfward/gouuid => ../
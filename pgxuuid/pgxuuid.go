@@ -0,0 +1,151 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package pgxuuid registers the UUID types of github.com/edwardfward/gouuid
+// with the type map of pgx v5, so uuid.UUID and uuid.NullUUID values travel
+// in the 16 byte binary format of Postgres uuid columns instead of as text.
+// It is a separate module so the uuid package itself takes no third-party
+// dependencies.
+//
+// Register the types on each connection, e.g. in the AfterConnect hook of a
+// pgxpool.Config:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxuuid.Register(conn.TypeMap())
+//		return nil
+//	}
+package pgxuuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	uuid "github.com/edwardfward/gouuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Register registers Codec for the uuid type with m, and uuid.UUID and
+// uuid.NullUUID as values of the uuid type, so they are encoded in the
+// binary format even when the type of a parameter is not known.
+func Register(m *pgtype.Map) {
+	m.RegisterType(&pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID,
+		Codec: Codec{}})
+	m.RegisterDefaultPgType(uuid.UUID(nil), "uuid")
+	m.RegisterDefaultPgType(uuid.NullUUID{}, "uuid")
+}
+
+// Codec is the pgtype.Codec of the uuid type. It encodes and scans uuid.UUID
+// and uuid.NullUUID directly and all other values as pgtype.UUIDCodec does.
+// A nil uuid.UUID and an invalid uuid.NullUUID are written as NULL, and NULL
+// scans to them, as in database/sql.
+type Codec struct {
+	pgtype.UUIDCodec
+}
+
+// PlanEncode implements pgtype.Codec.
+func (c Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16,
+	value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case uuid.UUID, uuid.NullUUID:
+		return encodePlan{format: format}
+	}
+
+	return c.UUIDCodec.PlanEncode(m, oid, format, value)
+}
+
+// PlanScan implements pgtype.Codec.
+func (c Codec) PlanScan(m *pgtype.Map, oid uint32, format int16,
+	target any) pgtype.ScanPlan {
+	switch target.(type) {
+	case *uuid.UUID, *uuid.NullUUID:
+		return scanPlan{format: format}
+	}
+
+	return c.UUIDCodec.PlanScan(m, oid, format, target)
+}
+
+// DecodeDatabaseSQLValue implements pgtype.Codec, returning the canonical
+// form of the UUID.
+func (c Codec) DecodeDatabaseSQLValue(m *pgtype.Map, oid uint32, format int16,
+	src []byte) (driver.Value, error) {
+	var result uuid.UUID
+	if err := (scanPlan{format: format}).Scan(src, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Value()
+}
+
+// DecodeValue implements pgtype.Codec, returning a uuid.UUID, or nil for NULL.
+func (c Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16,
+	src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	var result uuid.UUID
+	if err := (scanPlan{format: format}).Scan(src, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// encodePlan encodes a uuid.UUID or uuid.NullUUID in format.
+type encodePlan struct {
+	format int16
+}
+
+func (p encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	var id uuid.UUID
+	switch value := value.(type) {
+	case uuid.UUID:
+		id = value
+	case uuid.NullUUID:
+		if value.Valid {
+			id = value.UUID
+		}
+	}
+	if id == nil {
+		return nil, nil
+	}
+
+	if p.format == pgtype.BinaryFormatCode {
+		return id.AppendBinary(buf)
+	}
+
+	return id.AppendText(buf)
+}
+
+// scanPlan scans a uuid column in format into a *uuid.UUID or
+// *uuid.NullUUID.
+type scanPlan struct {
+	format int16
+}
+
+func (p scanPlan) Scan(src []byte, target any) error {
+	var id uuid.UUID
+	if src != nil {
+		var err error
+		if p.format == pgtype.BinaryFormatCode {
+			if len(src) != 16 {
+				return fmt.Errorf("pgxuuid: invalid length for uuid: %d",
+					len(src))
+			}
+			id = uuid.FromArray([16]byte(src))
+		} else if id, err = uuid.Parse(string(src)); err != nil {
+			return err
+		}
+	}
+
+	switch target := target.(type) {
+	case *uuid.UUID:
+		*target = id
+	case *uuid.NullUUID:
+		*target = uuid.NullUUID{UUID: id, Valid: id != nil}
+	default:
+		return fmt.Errorf("pgxuuid: cannot scan into %T", target)
+	}
+
+	return nil
+}
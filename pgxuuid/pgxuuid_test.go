@@ -0,0 +1,79 @@
+package pgxuuid
+
+import (
+	"bytes"
+	"testing"
+
+	uuid "github.com/edwardfward/gouuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestRegister(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+	in := uuid.Must(uuid.Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+	buf, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, in, nil)
+	if err != nil {
+		t.Fatalf("failed to encode UUID: %v", err)
+	}
+	if !bytes.Equal(buf, in) {
+		t.Errorf("Failed to encode UUID in binary. Expected: %x, "+
+			"Received: %x", []byte(in), buf)
+	}
+
+	var out uuid.UUID
+	if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, buf,
+		&out); err != nil || !out.Equal(in) {
+		t.Errorf("Failed to scan binary UUID. Expected: %s, Received: %s",
+			in, out)
+	}
+
+	text := []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	out = nil
+	if err := m.Scan(pgtype.UUIDOID, pgtype.TextFormatCode, text,
+		&out); err != nil || !out.Equal(in) {
+		t.Errorf("Failed to scan text UUID. Expected: %s, Received: %s",
+			in, out)
+	}
+
+	// the type of the value selects the codec when the OID is not known
+	if plan := m.PlanEncode(0, pgtype.BinaryFormatCode, in); plan == nil {
+		t.Errorf("failed to plan encoding a UUID without an OID")
+	}
+}
+
+func TestRegisterNull(t *testing.T) {
+	m := pgtype.NewMap()
+	Register(m)
+
+	for _, value := range []any{uuid.UUID(nil), uuid.NullUUID{}} {
+		buf, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, value,
+			nil)
+		if err != nil || buf != nil {
+			t.Errorf("Failed to encode %#v as NULL. Received: %x, %v",
+				value, buf, err)
+		}
+	}
+
+	out := uuid.NewV4()
+	if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil,
+		&out); err != nil || out != nil {
+		t.Errorf("Failed to scan NULL into UUID. Received: %s", out)
+	}
+
+	null := uuid.NullUUID{UUID: uuid.NewV4(), Valid: true}
+	if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil,
+		&null); err != nil || null.Valid {
+		t.Errorf("Failed to scan NULL into NullUUID. Received: %+v", null)
+	}
+
+	in := uuid.NewV4()
+	buf, _ := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode,
+		uuid.NullUUID{UUID: in, Valid: true}, nil)
+	if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, buf,
+		&null); err != nil || !null.Valid || !null.UUID.Equal(in) {
+		t.Errorf("Failed to scan NullUUID. Expected: %s, Received: %+v", in,
+			null)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"sync"
+)
+
+// Pool keeps UUIDs of one version pre-generated and refills itself in a
+// background goroutine when it drains below a watermark, so the latency of
+// acquiring an identifier is decoupled from the cost of the random source
+// and the clock. Time-based UUIDs taken from a Pool carry the time they were
+// generated, not the time they were taken. A Pool is safe for concurrent use.
+type Pool struct {
+	g         *Generator
+	version   Version
+	watermark int
+	uuids     chan UUID
+	refill    chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewPool returns a Pool of size UUIDs of the given version generated by the
+// package-level functions, refilled when fewer than watermark remain. Only
+// versions generated by New without options are supported.
+func NewPool(version Version, size, watermark int) *Pool {
+	return Default().NewPool(version, size, watermark)
+}
+
+// NewPool returns a Pool of UUIDs generated by g, see the package-level
+// NewPool.
+func (g *Generator) NewPool(version Version, size, watermark int) *Pool {
+	p := &Pool{
+		g:         g,
+		version:   version,
+		watermark: watermark,
+		uuids:     make(chan UUID, size),
+		refill:    make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+	go p.run()
+	p.requestRefill()
+
+	return p
+}
+
+// Get returns a pre-generated UUID, or generates one if the Pool is empty.
+// Returns an error if generation fails.
+func (p *Pool) Get() (UUID, error) {
+	select {
+	case uuid := <-p.uuids:
+		if len(p.uuids) < p.watermark {
+			p.requestRefill()
+		}
+		return uuid, nil
+	default:
+		p.requestRefill()
+		return p.g.New(p.version)
+	}
+}
+
+// Len returns the number of UUIDs currently pre-generated.
+func (p *Pool) Len() int {
+	return len(p.uuids)
+}
+
+// Close stops the refill goroutine. Get keeps working, generating UUIDs
+// itself once the remaining ones are taken.
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+// requestRefill wakes the refill goroutine unless a refill is pending.
+func (p *Pool) requestRefill() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// run fills the Pool each time a refill is requested, until Close.
+func (p *Pool) run() {
+	for {
+		select {
+		case <-p.refill:
+		case <-p.done:
+			return
+		}
+
+		for len(p.uuids) < cap(p.uuids) {
+			uuid, err := p.g.New(p.version)
+			if err != nil {
+				// Get reports the error when the Pool runs dry
+				break
+			}
+
+			select {
+			case p.uuids <- uuid:
+			case <-p.done:
+				return
+			default:
+				// filled concurrently
+			}
+		}
+	}
+}
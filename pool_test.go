@@ -0,0 +1,63 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	p := NewPool(Version4, 64, 16)
+	defer p.Close()
+
+	// wait for the initial fill
+	for i := 0; p.Len() < 64 && i < 1000; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if p.Len() != 64 {
+		t.Fatalf("Failed to fill pool. Expected: 64, Received: %d", p.Len())
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i < 200; i++ {
+		result, err := p.Get()
+		if err != nil {
+			t.Fatalf("failed to get UUID: %v", err)
+		}
+		if result.Version() != Version4 {
+			t.Fatalf("incorrect version detected: %s", result.Version())
+		}
+		if seen[result.String()] {
+			t.Fatalf("Duplicate UUIDs detected: %s", result)
+		}
+		seen[result.String()] = true
+	}
+
+	// the pool refills after being drained below the watermark; the Get that
+	// leaves fewer than 16 UUIDs requests the refill
+	for p.Len() >= 16 {
+		if _, err := p.Get(); err != nil {
+			t.Fatalf("failed to get UUID: %v", err)
+		}
+	}
+	for i := 0; p.Len() < 64 && i < 1000; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if p.Len() != 64 {
+		t.Errorf("Failed to refill pool. Expected: 64, Received: %d", p.Len())
+	}
+
+	empty := NewPool(Version5, 4, 1)
+	empty.Close()
+	if _, err := empty.Get(); err == nil {
+		t.Errorf("generated a version 5 UUID without a name")
+	}
+}
+
+func BenchmarkPoolGet(b *testing.B) {
+	p := NewPool(Version4, 1024, 256)
+	defer p.Close()
+
+	for i := 0; i < b.N; i++ {
+		p.Get()
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"context"
+)
+
+// Produce generates UUIDs of the given version in a background goroutine into
+// a channel with the given buffer, so request handlers can receive pre-made
+// identifiers without waiting on the random source or the clock. The channel
+// is closed when ctx is done, or when generation fails, e.g. for versions
+// that require options such as Version5.
+func Produce(ctx context.Context, version Version, buffer int) <-chan UUID {
+	return Default().Produce(ctx, version, buffer)
+}
+
+// Produce generates UUIDs of the given version using g, see the package-level
+// Produce.
+func (g *Generator) Produce(ctx context.Context, version Version, buffer int) <-chan UUID {
+	ch := make(chan UUID, buffer)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			uuid, err := g.New(version)
+			if err != nil {
+				return
+			}
+
+			select {
+			case ch <- uuid:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,32 @@
+package uuid
+
+import (
+	"context"
+	"testing"
+)
+
+func TestProduce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Produce(ctx, Version7, 16)
+
+	last := Nil
+	for i := 0; i < 100; i++ {
+		result := <-ch
+		if result.Version() != Version7 {
+			t.Fatalf("incorrect version detected: %s", result.Version())
+		}
+		if !last.Less(result) {
+			t.Fatalf("UUID %s is not greater than %s", result, last)
+		}
+		last = result
+	}
+
+	// after cancellation the channel drains and closes
+	cancel()
+	for range ch {
+	}
+
+	if _, ok := <-Produce(context.Background(), Version5, 1); ok {
+		t.Errorf("produced a version 5 UUID without a name")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Provider is the UUID creation surface of a Generator, for services that
+// inject it as a dependency. *Generator implements Provider, and Recorder
+// implements it for tests. It is not named Generator because that name
+// belongs to the concrete type.
+type Provider interface {
+	New(version Version, opts ...Option) (UUID, error)
+	NewV1() UUID
+	NewV4() UUID
+	NewV6() UUID
+	NewV7() UUID
+}
+
+var _ Provider = (*Generator)(nil)
+var _ Provider = (*Recorder)(nil)
+
+// Call is a call made to a Recorder.
+type Call struct {
+	// Method is the name of the method called, e.g. NewV4.
+	Method string
+	// Version is the version requested.
+	Version Version
+	// Result is the UUID returned, nil if the script was exhausted.
+	Result UUID
+}
+
+// Recorder is a Provider for tests that returns a scripted sequence of UUIDs
+// and records each call, so tests can assert exactly which identifiers a
+// service minted. The scripted UUIDs are returned as given whatever version
+// is requested. Once the script is exhausted New returns an error and the
+// other methods panic. A Recorder is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	script []UUID
+	calls  []Call
+}
+
+// NewRecorder returns a Recorder returning the UUIDs of script in order.
+func NewRecorder(script ...UUID) *Recorder {
+	return &Recorder{script: script}
+}
+
+// New returns the next scripted UUID.
+func (r *Recorder) New(version Version, opts ...Option) (UUID, error) {
+	return r.next("New", version)
+}
+
+// NewV1 returns the next scripted UUID.
+func (r *Recorder) NewV1() UUID {
+	return Must(r.next("NewV1", Version1))
+}
+
+// NewV4 returns the next scripted UUID.
+func (r *Recorder) NewV4() UUID {
+	return Must(r.next("NewV4", Version4))
+}
+
+// NewV6 returns the next scripted UUID.
+func (r *Recorder) NewV6() UUID {
+	return Must(r.next("NewV6", Version6))
+}
+
+// NewV7 returns the next scripted UUID.
+func (r *Recorder) NewV7() UUID {
+	return Must(r.next("NewV7", Version7))
+}
+
+// Calls returns the calls made so far, in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// Minted returns the UUIDs returned so far, in order.
+func (r *Recorder) Minted() []UUID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var minted []UUID
+	for _, call := range r.calls {
+		if call.Result != nil {
+			minted = append(minted, call.Result)
+		}
+	}
+
+	return minted
+}
+
+// next records a call and returns the next scripted UUID.
+func (r *Recorder) next(method string, version Version) (UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	call := Call{Method: method, Version: version}
+	if len(r.script) > 0 {
+		call.Result, r.script = r.script[0], r.script[1:]
+	}
+	r.calls = append(r.calls, call)
+
+	if call.Result == nil {
+		return nil, fmt.Errorf("uuid: recorder script exhausted by %s", method)
+	}
+
+	return call.Result, nil
+}
@@ -0,0 +1,39 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	first, second := NewV4(), NewV7()
+	recorder := NewRecorder(first, second)
+
+	var provider Provider = recorder
+	if result := provider.NewV4(); !result.Equal(first) {
+		t.Errorf("Failed to return scripted UUID. Expected: %s, Received: %s",
+			first, result)
+	}
+	if result, err := provider.New(Version7); err != nil || !result.Equal(second) {
+		t.Errorf("Failed to return scripted UUID. Expected: %s, Received: %s",
+			second, result)
+	}
+	if _, err := provider.New(Version4); err == nil {
+		t.Errorf("returned a UUID after the script was exhausted")
+	}
+
+	calls := recorder.Calls()
+	if len(calls) != 3 || calls[0].Method != "NewV4" ||
+		calls[1].Version != Version7 || calls[2].Result != nil {
+		t.Errorf("incorrect calls recorded: %+v", calls)
+	}
+	if minted := recorder.Minted(); len(minted) != 2 || !minted[1].Equal(second) {
+		t.Errorf("incorrect UUIDs minted: %v", minted)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewV1 did not panic after the script was exhausted")
+		}
+	}()
+	provider.NewV1()
+}
@@ -0,0 +1,227 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	mrandv2 "math/rand/v2"
+	"sync"
+	"time"
+)
+
+// EntropyPolicy selects how UUID generation behaves when the random source
+// returns an error, including while drawing the clock sequence and random
+// node ID of the time-based versions.
+type EntropyPolicy int32
+
+const (
+	// EntropyReturnError returns the error from the constructors that return
+	// errors, such as NewV4E, and panics in the ones that do not, such as
+	// NewV4. This is the default.
+	EntropyReturnError EntropyPolicy = iota
+	// EntropyRetry retries the read with exponential backoff before failing
+	// as EntropyReturnError does, riding out transient failures at the cost of
+	// latency.
+	EntropyRetry
+	// EntropyPanic panics on the first failure, even in the constructors that
+	// return errors, for processes that must never continue without entropy.
+	EntropyPanic
+)
+
+const (
+	// entropyRetries is the number of retries made by EntropyRetry.
+	entropyRetries = 5
+	// entropyBackoff is the delay before the first retry, doubling for each
+	// subsequent retry.
+	entropyBackoff = time.Millisecond
+)
+
+// randPoolSize is the number of random bytes read at a time in pooled mode,
+// enough for 256 Version 4 UUIDs.
+const randPoolSize = 16 * 256
+
+// randPool buffers random bytes so Version 4 UUIDs can be sliced from a
+// single large read, see EnableRandPool.
+type randPool struct {
+	sync.Mutex
+	buf [randPoolSize]byte
+	pos int
+}
+
+// SetRand sets the source of random bits used by the package, e.g. a
+// deterministic byte stream in tests or an HSM-backed RNG. Passing nil
+// restores the default, crypto/rand.
+func SetRand(r io.Reader) {
+	Default().SetRand(r)
+}
+
+// SetRand sets the source of random bits used by g. Passing nil restores the
+// default, crypto/rand.
+func (g *Generator) SetRand(r io.Reader) {
+	g.setRand(r)
+}
+
+// SetInsecureRand selects whether random bits come from math/rand instead of
+// crypto/rand, which is the default. math/rand is faster, but its output is
+// predictable, so UUIDs generated with it must not be relied on to be
+// unguessable. SetInsecureRand(false) restores crypto/rand, replacing any
+// source set with SetRand.
+func SetInsecureRand(enabled bool) {
+	Default().SetInsecureRand(enabled)
+}
+
+// SetInsecureRand selects whether g reads random bits from math/rand, see the
+// package-level SetInsecureRand.
+func (g *Generator) SetInsecureRand(enabled bool) {
+	if enabled {
+		g.setRand(mathRandReader{})
+	} else {
+		g.setRand(nil)
+	}
+}
+
+// EnableRandPool makes NewV4 read random bits in blocks of 4096 bytes and
+// slice UUIDs from the buffer, cutting the cost of reading the random source
+// for each UUID. The buffered bytes are held in memory until used, so the
+// pool should not be enabled where that is a concern.
+func EnableRandPool() {
+	Default().EnableRandPool()
+}
+
+// EnableRandPool makes g buffer random bits for Version 4 UUIDs, see the
+// package-level EnableRandPool.
+func (g *Generator) EnableRandPool() {
+	g.pool.Store(&randPool{pos: randPoolSize})
+}
+
+// DisableRandPool makes NewV4 read the random source for each UUID again.
+// This is the default.
+func DisableRandPool() {
+	Default().DisableRandPool()
+}
+
+// DisableRandPool makes g read the random source for each Version 4 UUID.
+func (g *Generator) DisableRandPool() {
+	g.pool.Store(nil)
+}
+
+// SetEntropyPolicy sets how UUID generation behaves when the random source
+// returns an error.
+func SetEntropyPolicy(policy EntropyPolicy) {
+	Default().SetEntropyPolicy(policy)
+}
+
+// SetEntropyPolicy sets how g behaves when its random source returns an
+// error.
+func (g *Generator) SetEntropyPolicy(policy EntropyPolicy) {
+	g.entropyPolicy.Store(int32(policy))
+}
+
+// mathRandReader reads from the math/rand global source.
+type mathRandReader struct{}
+
+func (mathRandReader) Read(b []byte) (int, error) {
+	return mrand.Read(b)
+}
+
+// InsecureChaCha8 returns a source of random bits for SetRand backed by a
+// math/rand/v2 ChaCha8 generator seeded with seed, for simulations and test
+// data where throughput matters more than unpredictability. The same seed
+// always produces the same stream of bits, so UUIDs generated from it are
+// guessable. The returned source is safe for concurrent use.
+func InsecureChaCha8(seed [32]byte) io.Reader {
+	return &chaCha8Reader{chaCha8: mrandv2.NewChaCha8(seed)}
+}
+
+// chaCha8Reader serializes access to a ChaCha8 generator, which is not safe
+// for concurrent use on its own.
+type chaCha8Reader struct {
+	sync.Mutex
+	chaCha8 *mrandv2.ChaCha8
+}
+
+func (r *chaCha8Reader) Read(b []byte) (int, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.chaCha8.Read(b)
+}
+
+func (g *Generator) setRand(r io.Reader) {
+	if r == nil {
+		r = crand.Reader
+	}
+	g.rand.Store(&r)
+}
+
+// readRandom fills b with random bytes, returning any error from the random
+// source so it can be surfaced instead of producing low-quality UUIDs.
+func (g *Generator) readRandom(b []byte) error {
+	var r io.Reader = crand.Reader
+	if p := g.rand.Load(); p != nil {
+		r = *p
+	}
+
+	_, err := io.ReadFull(r, b)
+	if err == nil {
+		return nil
+	}
+	g.randErrors.Add(1)
+
+	switch EntropyPolicy(g.entropyPolicy.Load()) {
+	case EntropyRetry:
+		backoff := entropyBackoff
+		for i := 0; i < entropyRetries && err != nil; i++ {
+			time.Sleep(backoff)
+			backoff *= 2
+			if _, err = io.ReadFull(r, b); err != nil {
+				g.randErrors.Add(1)
+			}
+		}
+	case EntropyPanic:
+		panic(fmt.Errorf("uuid: random source failed: %w", err))
+	}
+
+	return err
+}
+
+// readRandomPooled fills b from the random pool if it is enabled, refilling
+// the pool from the random source as needed, and from the random source
+// otherwise.
+func (g *Generator) readRandomPooled(b []byte) error {
+	pool := g.pool.Load()
+	if pool == nil || len(b) > randPoolSize {
+		return g.readRandom(b)
+	}
+
+	pool.Lock()
+	defer pool.Unlock()
+
+	if pool.pos+len(b) > randPoolSize {
+		if err := g.readRandom(pool.buf[:]); err != nil {
+			return err
+		}
+		pool.pos = 0
+		g.poolRefills.Add(1)
+	}
+	pool.pos += copy(b, pool.buf[pool.pos:])
+
+	return nil
+}
+
+// randomUint32 returns 32 random bits, or an error if the random source
+// fails.
+func (g *Generator) randomUint32() (uint32, error) {
+	var b [4]byte
+	if err := g.readRandom(b[:]); err != nil {
+		return 0, err
+	}
+
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3]),
+		nil
+}
@@ -0,0 +1,196 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSetInsecureRand(t *testing.T) {
+	SetInsecureRand(true)
+	defer SetInsecureRand(false)
+
+	result := NewV4()
+	if result.Version() != Version4 || result.Variant() != VariantRFC4122 {
+		t.Fatalf("incorrect version or variant detected")
+	}
+
+	SetInsecureRand(false)
+	if NewV4().Equal(result) {
+		t.Errorf("Duplicate UUIDs detected")
+	}
+}
+
+func BenchmarkNewV4Insecure(b *testing.B) {
+	SetInsecureRand(true)
+	defer SetInsecureRand(false)
+
+	for i := 0; i < b.N; i++ {
+		NewV4()
+	}
+}
+
+func TestSetRand(t *testing.T) {
+	SetRand(bytes.NewReader(bytes.Repeat([]byte{0xAB}, 16)))
+	defer SetRand(nil)
+
+	result := NewV4()
+	expected := "abababab-abab-4bab-abab-abababababab"
+	if PrintUUID(result) != expected {
+		t.Errorf("Failed to read injected source. Expected: %s, "+
+			"Received: %s", expected, PrintUUID(result))
+	}
+
+	// the source is now exhausted, which must be surfaced as an error
+	if _, err := NewV4E(); err == nil {
+		t.Errorf("ignored an exhausted random source")
+	}
+	if _, err := NewV7E(); err == nil {
+		t.Errorf("ignored an exhausted random source")
+	}
+}
+
+func TestEnableRandPool(t *testing.T) {
+	EnableRandPool()
+	defer DisableRandPool()
+
+	// generate enough UUIDs to refill the pool several times
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		result := NewV4()
+		if result.Version() != Version4 || result.Variant() != VariantRFC4122 {
+			t.Fatalf("incorrect version or variant detected on test %d", i)
+		}
+		if seen[result.String()] {
+			t.Fatalf("Duplicate UUIDs detected on test %d", i)
+		}
+		seen[result.String()] = true
+	}
+
+	// errors from the random source are surfaced when refilling
+	SetRand(bytes.NewReader(nil))
+	defer SetRand(nil)
+	EnableRandPool()
+	if _, err := NewV4E(); err == nil {
+		t.Errorf("ignored an exhausted random source")
+	}
+}
+
+func BenchmarkNewV4Pool(b *testing.B) {
+	EnableRandPool()
+	defer DisableRandPool()
+
+	for i := 0; i < b.N; i++ {
+		NewV4()
+	}
+}
+
+func TestInsecureChaCha8(t *testing.T) {
+	var seed [32]byte
+	copy(seed[:], "chacha8 seed for uuid generation")
+
+	SetRand(InsecureChaCha8(seed))
+	first := []UUID{NewV4(), NewV4()}
+
+	SetRand(InsecureChaCha8(seed))
+	second := []UUID{NewV4(), NewV4()}
+	SetRand(nil)
+
+	for i := range first {
+		if !first[i].Equal(second[i]) {
+			t.Errorf("Failed to reproduce UUID %d. Expected: %s, "+
+				"Received: %s", i, first[i], second[i])
+		}
+	}
+	if first[0].Equal(first[1]) {
+		t.Errorf("Duplicate UUIDs detected")
+	}
+}
+
+func BenchmarkNewV4ChaCha8(b *testing.B) {
+	SetRand(InsecureChaCha8([32]byte{}))
+	defer SetRand(nil)
+
+	for i := 0; i < b.N; i++ {
+		NewV4()
+	}
+}
+
+// flakyReader fails the first failures reads and then returns 0xAB bytes.
+type flakyReader struct {
+	failures int
+}
+
+func (r *flakyReader) Read(b []byte) (int, error) {
+	if r.failures > 0 {
+		r.failures--
+		return 0, errors.New("entropy unavailable")
+	}
+	for i := range b {
+		b[i] = 0xAB
+	}
+	return len(b), nil
+}
+
+func TestSetEntropyPolicy(t *testing.T) {
+	defer SetRand(nil)
+	defer SetEntropyPolicy(EntropyReturnError)
+
+	SetRand(&flakyReader{failures: 1})
+	if _, err := NewV4E(); err == nil {
+		t.Errorf("ignored a failed random source")
+	}
+
+	SetEntropyPolicy(EntropyRetry)
+	SetRand(&flakyReader{failures: 2})
+	if _, err := NewV4E(); err != nil {
+		t.Errorf("failed to retry the random source: %v", err)
+	}
+	SetRand(&flakyReader{failures: entropyRetries + 1})
+	if _, err := NewV4E(); err == nil {
+		t.Errorf("ignored a failed random source after retries")
+	}
+
+	SetEntropyPolicy(EntropyPanic)
+	SetRand(&flakyReader{failures: 1})
+	defer func() {
+		if recover() == nil {
+			t.Errorf("failed to panic on a failed random source")
+		}
+	}()
+	NewV4E()
+}
+
+func TestSetEntropyPolicyTimeBased(t *testing.T) {
+	node := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}
+
+	// the clock sequence, the privacy node and the clock sequence again
+	g := NewGenerator(WithNodeID(node), WithPrivacyNode(),
+		WithRand(&flakyReader{failures: 3}))
+	if _, err := g.NewV1E(); err == nil {
+		t.Errorf("ignored a failed random source")
+	}
+
+	g = NewGenerator(WithNodeID(node), WithPrivacyNode(),
+		WithRand(&flakyReader{failures: 2}))
+	g.SetEntropyPolicy(EntropyRetry)
+	result, err := g.NewV1E()
+	if err != nil {
+		t.Fatalf("failed to retry the random source: %v", err)
+	}
+	if expected := bytes.Repeat([]byte{0xAB}, 6); !bytes.Equal(result[10:],
+		expected) {
+		t.Errorf("Failed to draw privacy node. Expected: %x, Received: %x",
+			expected, result[10:])
+	}
+
+	g = NewGenerator(WithNodeID(node), WithPrivacyNode(),
+		WithRand(&flakyReader{failures: 1}))
+	g.SetEntropyPolicy(EntropyPanic)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("failed to panic on a failed random source")
+		}
+	}()
+	g.NewV1E()
+}
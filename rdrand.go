@@ -0,0 +1,49 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// errRDRAND is returned when the RDRAND instruction keeps failing, which
+// happens when its hardware generator is exhausted or broken.
+var errRDRAND = errors.New("uuid: RDRAND failed")
+
+// RDRAND returns a source of random bits for SetRand and WithRand that reads
+// the hardware random number generator of the CPU with the RDRAND
+// instruction, for deployments whose policy requires it. Falls back to
+// crypto/rand on CPUs and architectures without RDRAND.
+//
+// It is not a latency optimization: crypto/rand, the default source, already
+// reads getrandom(2) on Linux without locking, through the vDSO on recent
+// kernels, and is faster than RDRAND there (see BenchmarkNewV4RDRAND).
+func RDRAND() io.Reader {
+	if !hasRDRAND {
+		return crand.Reader
+	}
+
+	return rdrandReader{}
+}
+
+type rdrandReader struct{}
+
+func (rdrandReader) Read(b []byte) (int, error) {
+	var word [8]byte
+	for n := 0; n < len(b); {
+		v, ok := rdrand64()
+		// some AMD CPUs report success while returning all ones
+		if !ok || v == ^uint64(0) {
+			return n, errRDRAND
+		}
+		binary.LittleEndian.PutUint64(word[:], v)
+		n += copy(b[n:], word[:])
+	}
+
+	return len(b), nil
+}
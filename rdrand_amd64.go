@@ -0,0 +1,15 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// hasRDRAND reports whether the CPU supports the RDRAND instruction.
+var hasRDRAND = cpuidRDRAND()
+
+// cpuidRDRAND reports the RDRAND feature bit of CPUID leaf 1.
+func cpuidRDRAND() bool
+
+// rdrand64 returns 64 random bits from RDRAND, retrying as recommended by
+// Intel before reporting failure.
+func rdrand64() (uint64, bool)
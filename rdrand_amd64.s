@@ -0,0 +1,33 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+#include "textflag.h"
+
+// func cpuidRDRAND() bool
+TEXT ·cpuidRDRAND(SB), NOSPLIT, $0-1
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	SHRL $30, CX
+	ANDL $1, CX
+	MOVB CX, ret+0(FP)
+	RET
+
+// func rdrand64() (uint64, bool)
+TEXT ·rdrand64(SB), NOSPLIT, $0-9
+	MOVL $10, CX
+
+retry:
+	RDRANDQ AX
+	JCS  ok
+	DECL CX
+	JNZ  retry
+	MOVQ $0, ret+0(FP)
+	MOVB $0, ret1+8(FP)
+	RET
+
+ok:
+	MOVQ AX, ret+0(FP)
+	MOVB $1, ret1+8(FP)
+	RET
@@ -0,0 +1,14 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !amd64
+
+package uuid
+
+// hasRDRAND is false, RDRAND is only used on amd64.
+const hasRDRAND = false
+
+func rdrand64() (uint64, bool) {
+	return 0, false
+}
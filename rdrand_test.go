@@ -0,0 +1,35 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRDRAND(t *testing.T) {
+	b := make([]byte, 1021)
+	n, err := RDRAND().Read(b)
+	if err != nil || n != len(b) {
+		t.Fatalf("failed to read random bytes: %d, %v", n, err)
+	}
+	if bytes.Equal(b, make([]byte, len(b))) {
+		t.Errorf("read only zero bytes")
+	}
+
+	g := NewGenerator(WithRand(RDRAND()))
+	if a, b := g.NewV4(), g.NewV4(); a.Equal(b) {
+		t.Errorf("Duplicate UUIDs detected")
+	}
+	if hasRDRAND {
+		if health := g.Health(); health.RandSource != "rdrand" ||
+			!health.SecureRand {
+			t.Errorf("incorrect random source reported: %+v", health)
+		}
+	}
+}
+
+func BenchmarkNewV4RDRAND(b *testing.B) {
+	g := NewGenerator(WithRand(RDRAND()))
+	for i := 0; i < b.N; i++ {
+		g.NewV4()
+	}
+}
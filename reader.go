@@ -0,0 +1,59 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"io"
+)
+
+// NewUUIDReader returns an io.Reader emitting an endless stream of UUIDs of
+// the given version, generated as by New with the same options, for piping
+// test data and seeding load generators. By default each UUID is emitted as
+// its raw 16 bytes; with WithText each is emitted in canonical form followed
+// by a newline. Read returns any error from New.
+func NewUUIDReader(version Version, opts ...Option) io.Reader {
+	return Default().NewUUIDReader(version, opts...)
+}
+
+// NewUUIDReader returns an io.Reader emitting an endless stream of UUIDs of
+// the given version generated by g, see the package-level NewUUIDReader.
+func (g *Generator) NewUUIDReader(version Version, opts ...Option) io.Reader {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &uuidReader{g: g, version: version, opts: opts, text: o.text}
+}
+
+type uuidReader struct {
+	g       *Generator
+	version Version
+	opts    []Option
+	text    bool
+	pending []byte // the unread part of the current UUID
+}
+
+func (r *uuidReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.pending) == 0 {
+			uuid, err := r.g.New(r.version, r.opts...)
+			if err != nil {
+				return n, err
+			}
+			r.pending = uuid
+			if r.text {
+				r.pending = append([]byte(uuid.String()), '\n')
+			}
+		}
+
+		copied := copy(p[n:], r.pending)
+		r.pending = r.pending[copied:]
+		n += copied
+	}
+
+	return n, nil
+}
@@ -0,0 +1,49 @@
+package uuid
+
+import (
+	"bufio"
+	"io"
+	"testing"
+)
+
+func TestNewUUIDReader(t *testing.T) {
+	// read in chunks which do not line up with the 16 byte UUIDs
+	r := NewUUIDReader(Version4)
+	b := make([]byte, 16*100)
+	for i := 0; i < len(b); i += 10 {
+		if _, err := io.ReadFull(r, b[i:min(i+10, len(b))]); err != nil {
+			t.Fatalf("failed to read UUIDs: %v", err)
+		}
+	}
+	for i := 0; i < len(b); i += 16 {
+		if UUID(b[i:i+16]).Version() != Version4 {
+			t.Fatalf("incorrect version detected at byte %d", i)
+		}
+	}
+
+	scanner := bufio.NewScanner(NewUUIDReader(Version7, WithText()))
+	for i := 0; i < 10 && scanner.Scan(); i++ {
+		result, err := ParseStrict(scanner.Text())
+		if err != nil {
+			t.Fatalf("failed to parse line %d: %v", i, err)
+		}
+		if result.Version() != Version7 {
+			t.Fatalf("incorrect version detected on line %d", i)
+		}
+	}
+
+	if _, err := NewUUIDReader(Version(9)).Read(b); err == nil {
+		t.Errorf("read UUIDs of an unknown version")
+	}
+
+	// the reader keeps using its generator when the default changes
+	first, second := NewSeededGenerator(42), NewSeededGenerator(42)
+	r = first.NewUUIDReader(Version4)
+	SetDefault(NewGenerator())
+	defer SetDefault(nil)
+	if _, err := io.ReadFull(r, b[:16]); err != nil ||
+		!UUID(b[:16]).Equal(second.NewV4()) {
+		t.Errorf("Failed to read from the generator. Received: %s",
+			UUID(b[:16]))
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+var (
+	_ sql.Scanner      = (*UUID)(nil)
+	_ driver.Valuer    = UUID(nil)
+	_ sql.Scanner      = (*NullUUID)(nil)
+	_ driver.Valuer    = NullUUID{}
+	_ json.Marshaler   = NullUUID{}
+	_ json.Unmarshaler = (*NullUUID)(nil)
+)
+
+// Scan reads a UUID from a database column, implementing sql.Scanner. It
+// accepts a string or []byte in any of the forms accepted by Parse, 16 raw
+// bytes as stored in binary columns such as MySQL BINARY(16), and NULL, which
+// scans to a nil UUID.
+func (uuid *UUID) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*uuid = nil
+		return nil
+	case string:
+		return uuid.UnmarshalText([]byte(src))
+	case []byte:
+		if len(src) == 16 {
+			return uuid.UnmarshalBinary(src)
+		}
+		return uuid.UnmarshalText(src)
+	}
+
+	return fmt.Errorf("uuid: cannot scan %T into UUID", src)
+}
+
+// Value returns the canonical form of uuid, implementing driver.Valuer, so it
+// can be written to uuid, char and text columns. A nil UUID is written as
+// NULL.
+func (uuid UUID) Value() (driver.Value, error) {
+	if uuid == nil {
+		return nil, nil
+	}
+	text, err := uuid.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(text), nil
+}
+
+// NullUUID represents a UUID that may be null, like sql.NullString, for
+// nullable columns and optional JSON fields.
+type NullUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan implements sql.Scanner, see UUID.Scan.
+func (n *NullUUID) Scan(src any) error {
+	if src == nil {
+		n.UUID, n.Valid = nil, false
+		return nil
+	}
+	if err := n.UUID.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+
+	return nil
+}
+
+// Value implements driver.Valuer, writing NULL unless n is valid.
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.UUID.Value()
+}
+
+// MarshalJSON implements json.Marshaler, encoding null unless n is valid. A
+// valid Nil UUID is always encoded as a string.
+func (n NullUUID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	text, err := n.UUID.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Null and the empty string
+// decode to an invalid NullUUID.
+func (n *NullUUID) UnmarshalJSON(data []byte) error {
+	n.UUID, n.Valid = nil, false
+	if string(data) == "null" {
+		return nil
+	}
+	if err := n.UUID.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = n.UUID != nil
+
+	return nil
+}
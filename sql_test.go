@@ -0,0 +1,90 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	canonical := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	for _, src := range []any{canonical, []byte(canonical),
+		[]byte(NamespaceDNS), "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}"} {
+		var result UUID
+		if err := result.Scan(src); err != nil || !result.Equal(NamespaceDNS) {
+			t.Errorf("Failed to scan %v. Expected: %s, Received: %s", src,
+				NamespaceDNS, result)
+		}
+	}
+
+	result := NewV4()
+	if err := result.Scan(nil); err != nil || result != nil {
+		t.Errorf("Failed to scan NULL. Expected: nil, Received: %s", result)
+	}
+
+	for _, src := range []any{"nope", []byte{1, 2, 3}, 42} {
+		if err := result.Scan(src); err == nil {
+			t.Errorf("scanned invalid UUID %v", src)
+		}
+	}
+}
+
+func TestValue(t *testing.T) {
+	value, err := NamespaceDNS.Value()
+	if err != nil || value != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("Failed to convert UUID to a value. Expected: %s, "+
+			"Received: %v", NamespaceDNS, value)
+	}
+	if value, err := UUID(nil).Value(); err != nil || value != nil {
+		t.Errorf("Failed to convert nil UUID to NULL. Received: %v", value)
+	}
+	if _, err := (UUID{1, 2, 3}).Value(); err == nil {
+		t.Errorf("converted a UUID of invalid length")
+	}
+}
+
+func TestNullUUID(t *testing.T) {
+	var n NullUUID
+	if err := n.Scan([]byte(NamespaceDNS)); err != nil || !n.Valid ||
+		!n.UUID.Equal(NamespaceDNS) {
+		t.Errorf("Failed to scan NullUUID. Expected: %s, Received: %+v",
+			NamespaceDNS, n)
+	}
+	if value, err := n.Value(); err != nil || value != NamespaceDNS.String() {
+		t.Errorf("Failed to convert NullUUID to a value. Expected: %s, "+
+			"Received: %v", NamespaceDNS, value)
+	}
+	if err := n.Scan("nope"); err == nil || n.Valid {
+		t.Errorf("scanned invalid UUID into a valid NullUUID")
+	}
+	if err := n.Scan(nil); err != nil || n.Valid || n.UUID != nil {
+		t.Errorf("Failed to scan NULL into NullUUID: %+v", n)
+	}
+	if value, err := n.Value(); err != nil || value != nil {
+		t.Errorf("Failed to convert invalid NullUUID to NULL. Received: %v",
+			value)
+	}
+}
+
+func TestNullUUIDJSON(t *testing.T) {
+	type record struct {
+		ID     NullUUID `json:"id"`
+		Parent NullUUID `json:"parent"`
+	}
+
+	in := record{ID: NullUUID{UUID: Nil, Valid: true}}
+	data, err := json.Marshal(in)
+	expected := `{"id":"00000000-0000-0000-0000-000000000000","parent":null}`
+	if err != nil || string(data) != expected {
+		t.Errorf("Failed to marshal NullUUID. Expected: %s, Received: %s",
+			expected, data)
+	}
+
+	out := record{Parent: NullUUID{UUID: NewV4(), Valid: true}}
+	if err := json.Unmarshal(data, &out); err != nil || !out.ID.Valid ||
+		!out.ID.UUID.Equal(Nil) || out.Parent.Valid {
+		t.Errorf("Failed to unmarshal NullUUID %s: %+v, %v", data, out, err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"nope"}`), &out); err == nil {
+		t.Errorf("unmarshaled an invalid NullUUID")
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// reference https://tools.ietf.org/html/rfc4122#section-4.2.1.1
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// State is the state of the time-based versions kept in stable storage: the
+// timestamp, clock sequence and node of the last time-based UUID.
+type State struct {
+	// Timestamp is the 60-bit count of 100ns intervals since the Gregorian
+	// epoch.
+	Timestamp     uint64
+	ClockSequence uint16
+	Node          [6]byte
+}
+
+// StableStorage keeps the State of a Generator across restarts. Store is
+// called with the Generator locked each time a time-based UUID is generated,
+// so implementations backed by Redis, etcd or a database should batch or
+// buffer writes. A StableStorage shared by several instances must only be
+// used by one Generator at a time.
+type StableStorage interface {
+	// Load returns the stored State, or the zero State if nothing has been
+	// stored.
+	Load() (State, error)
+	// Store replaces the stored State.
+	Store(State) error
+}
+
+// stateSize is the size of a State in a FileStorage.
+const stateSize = 16
+
+// FileStorage is a StableStorage keeping the State in a file, rewritten in
+// place by each Store.
+type FileStorage struct {
+	file *os.File
+}
+
+// NewFileStorage opens or creates the file at path for use as a
+// StableStorage.
+func NewFileStorage(path string) (*FileStorage, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("uuid: open state file: %w", err)
+	}
+
+	return &FileStorage{file: file}, nil
+}
+
+// Load reads the State from the file.
+func (s *FileStorage) Load() (State, error) {
+	var buf [stateSize]byte
+	if _, err := s.file.ReadAt(buf[:], 0); err != nil {
+		if errors.Is(err, io.EOF) {
+			return State{}, nil
+		}
+		return State{}, fmt.Errorf("uuid: read state file: %w", err)
+	}
+
+	var state State
+	state.Timestamp = binary.BigEndian.Uint64(buf[0:8])
+	state.ClockSequence = binary.BigEndian.Uint16(buf[8:10])
+	copy(state.Node[:], buf[10:16])
+
+	return state, nil
+}
+
+// Store overwrites the State in the file.
+func (s *FileStorage) Store(state State) error {
+	var buf [stateSize]byte
+	binary.BigEndian.PutUint64(buf[0:8], state.Timestamp)
+	binary.BigEndian.PutUint16(buf[8:10], state.ClockSequence)
+	copy(buf[10:16], state.Node[:])
+
+	_, err := s.file.WriteAt(buf[:], 0)
+	return err
+}
+
+// Close closes the file.
+func (s *FileStorage) Close() error {
+	return s.file.Close()
+}
+
+// PersistState keeps the timestamp, clock sequence and node of Version 1, 2
+// and 6 UUIDs in the file at path, as recommended by RFC 4122, so restarting
+// within the same 100ns interval or with a rolled-back clock cannot reissue
+// identical UUIDs. It is SetStableStorage with a FileStorage.
+func PersistState(path string) error {
+	return Default().PersistState(path)
+}
+
+// PersistState keeps the state of time-based UUIDs generated by g in the file
+// at path, see the package-level PersistState.
+func (g *Generator) PersistState(path string) error {
+	storage, err := NewFileStorage(path)
+	if err != nil {
+		return err
+	}
+	if err := g.SetStableStorage(storage); err != nil {
+		storage.Close()
+		return err
+	}
+
+	return nil
+}
+
+// SetStableStorage keeps the State of Version 1, 2 and 6 UUIDs in storage.
+// The State already stored is loaded: if it was stored with the same node the
+// clock sequence continues from it, otherwise the clock sequence stays
+// random. Failed writes are counted in Health.StateErrors. A previous
+// storage implementing io.Closer is closed.
+func SetStableStorage(storage StableStorage) error {
+	return Default().SetStableStorage(storage)
+}
+
+// SetStableStorage keeps the State of time-based UUIDs generated by g in
+// storage, see the package-level SetStableStorage.
+func (g *Generator) SetStableStorage(storage StableStorage) error {
+	g.ensureNode()
+	state, err := storage.Load()
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if state.Timestamp != 0 && bytes.Equal(state.Node[:], g.node) {
+		// continue from the stored state, so a clock behind the stored
+		// timestamp is detected as a rollback and changes the clock
+		// sequence
+		g.setClock(state.ClockSequence)
+		if state.Timestamp > g.v1Last.Load() {
+			g.v1Last.Store(state.Timestamp)
+		}
+		if state.Timestamp > g.v1Tick.Load() {
+			g.v1Tick.Store(state.Timestamp)
+		}
+	}
+	if closer, ok := g.state.(io.Closer); ok {
+		closer.Close()
+	}
+	g.state = storage
+	g.publishSequence()
+
+	return nil
+}
+
+// storeState writes the state of the last time-based UUID to the stable
+// storage, if any. Must be called with g.mu held.
+func (g *Generator) storeState(timestamp uint64) {
+	if g.state == nil {
+		return
+	}
+
+	state := State{Timestamp: timestamp, ClockSequence: g.clock}
+	copy(state.Node[:], g.node)
+	if err := g.state.Store(state); err != nil {
+		g.stateErrors.Add(1)
+	}
+}
@@ -0,0 +1,92 @@
+package uuid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPersistState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uuid.state")
+	node := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}
+	clock := &tickClock{ticks: nanos100sAt(seededEpoch)}
+
+	first := NewGenerator(WithNodeID(node), WithClock(clock))
+	if err := first.PersistState(path); err != nil {
+		t.Fatalf("failed to persist state: %v", err)
+	}
+	clock.ticks++
+	issued := map[string]bool{}
+	var last UUID
+	for i := 0; i < 100; i++ {
+		last = first.NewV1()
+		issued[last.String()] = true
+	}
+
+	// a restart with a clock that has not advanced must not reissue UUIDs
+	second := NewGenerator(WithNodeID(node), WithClock(clock))
+	if err := second.PersistState(path); err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	result := second.NewV1()
+	if v1ClockSequence(result) != (v1ClockSequence(last)+1)&0x3FFF {
+		t.Errorf("Failed to continue clock sequence. Expected: %d, "+
+			"Received: %d", v1ClockSequence(last)+1, v1ClockSequence(result))
+	}
+	for i := 0; i < 100; i++ {
+		if result := second.NewV1(); issued[result.String()] {
+			t.Fatalf("reissued UUID %s after restart", result)
+		}
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Size() != stateSize {
+		t.Errorf("incorrect state file: %v, %v", info, err)
+	}
+	if errors := second.Health().StateErrors; errors != 0 {
+		t.Errorf("failed to write state %d times", errors)
+	}
+}
+
+// memoryStorage is a StableStorage kept in memory.
+type memoryStorage struct {
+	state  State
+	stores int
+}
+
+func (s *memoryStorage) Load() (State, error) {
+	return s.state, nil
+}
+
+func (s *memoryStorage) Store(state State) error {
+	s.state = state
+	s.stores++
+	return nil
+}
+
+func TestSetStableStorage(t *testing.T) {
+	node := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}
+	clock := &tickClock{ticks: nanos100sAt(seededEpoch)}
+	storage := &memoryStorage{state: State{Timestamp: clock.ticks + 10,
+		ClockSequence: 41, Node: node}}
+
+	g := NewGenerator(WithNodeID(node), WithClock(clock))
+	if err := g.SetStableStorage(storage); err != nil {
+		t.Fatalf("failed to set stable storage: %v", err)
+	}
+	// the clock is behind the stored timestamp, so the clock sequence
+	// continues past the stored one
+	result := g.NewV1()
+	if clock := v1ClockSequence(result); clock != 42 {
+		t.Errorf("Failed to continue clock sequence. Expected: 42, "+
+			"Received: %d", clock)
+	}
+	if storage.stores != 1 || storage.state.Node != node ||
+		storage.state.Timestamp != v1Ticks(result) {
+		t.Errorf("incorrect stored state: %+v", storage.state)
+	}
+}
+
+// v1ClockSequence extracts the 14-bit clock sequence of a Version 1 UUID.
+func v1ClockSequence(uuid UUID) uint16 {
+	return uint16(uuid[8]&0x3F)<<8 | uint16(uuid[9])
+}
@@ -0,0 +1,50 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// Stats holds counters describing the work done by a Generator, for capacity
+// planning and debugging of hot generators. The counters only increase.
+type Stats struct {
+	// Generated is the number of UUIDs generated per version. Name-based
+	// versions are only counted when generated through Generator.New and
+	// Generator.NewV5Name.
+	Generated map[Version]uint64
+	// ClockSequenceChanges is the number of times the clock sequence of the
+	// time-based versions changed because the clock moved backwards.
+	ClockSequenceChanges uint64
+	// RandPoolRefills is the number of times the random pool was refilled,
+	// see EnableRandPool.
+	RandPoolRefills uint64
+	// CounterStalls is the number of times generation of a time-based UUID
+	// waited for the clock because the timestamp counter was exhausted, see
+	// CounterWait.
+	CounterStalls uint64
+}
+
+// GetStats returns the counters of the package-level functions.
+func GetStats() Stats {
+	return Default().Stats()
+}
+
+// Stats returns the counters of g. Each counter is read atomically, but the
+// counters are not read at a single instant.
+func (g *Generator) Stats() Stats {
+	stats := Stats{Generated: make(map[Version]uint64)}
+	for version := Version1; version <= Version8; version++ {
+		if n := g.generated[version].Load(); n != 0 {
+			stats.Generated[version] = n
+		}
+	}
+	stats.ClockSequenceChanges = g.clockChanges.Load()
+	stats.RandPoolRefills = g.poolRefills.Load()
+	stats.CounterStalls = g.counterStalls.Load()
+
+	return stats
+}
+
+// countGenerated adds n UUIDs of version to the counters of g.
+func (g *Generator) countGenerated(version Version, n int) {
+	g.generated[version].Add(uint64(n))
+}
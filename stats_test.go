@@ -0,0 +1,49 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	clock := &tickClock{ticks: nanos100sAt(seededEpoch)}
+	g := NewGenerator(WithClock(clock))
+	g.EnableRandPool()
+
+	g.NewV1()
+	g.NewV6()
+	g.NewV7()
+	for i := 0; i < randPoolSize/16+1; i++ {
+		g.NewV4()
+	}
+	g.AppendBatch(nil, Version1, 10)
+	g.New(Version5, WithName("test"))
+	// a version 3 UUID refused in FIPS mode is not counted
+	SetFIPSMode(true)
+	g.New(Version3, WithName("test"))
+	SetFIPSMode(false)
+	clock.ticks -= 100
+	g.NewV1()
+
+	// the random namespace of the generator is a version 4 UUID too
+	stats := g.Stats()
+	expected := map[Version]uint64{Version1: 12, Version4: randPoolSize/16 + 2,
+		Version5: 1, Version6: 1, Version7: 1}
+	for version, n := range expected {
+		if stats.Generated[version] != n {
+			t.Errorf("Failed to count %s UUIDs. Expected: %d, Received: %d",
+				version, n, stats.Generated[version])
+		}
+	}
+	if len(stats.Generated) != len(expected) {
+		t.Errorf("incorrect versions counted: %v", stats.Generated)
+	}
+	if stats.ClockSequenceChanges != 1 {
+		t.Errorf("Failed to count clock sequence changes. Expected: 1, "+
+			"Received: %d", stats.ClockSequenceChanges)
+	}
+	// one fill when the pool is first used and one when it runs out
+	if stats.RandPoolRefills != 2 {
+		t.Errorf("Failed to count pool refills. Expected: 2, Received: %d",
+			stats.RandPoolRefills)
+	}
+}
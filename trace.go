@@ -0,0 +1,66 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+)
+
+// TraceID returns uuid as a 16 byte W3C Trace Context trace ID, the layout of
+// trace.TraceID in OpenTelemetry, so a request ID can double as the trace ID.
+// The trace ID of the Nil UUID is invalid, so an error is returned for it.
+// Version 4 and 7 UUIDs end in random bits, as trace IDs are expected to.
+func (uuid UUID) TraceID() ([16]byte, error) {
+	if len(uuid) != 16 || uuid.IsNil() {
+		return [16]byte{}, fmt.Errorf("uuid: %s is not a valid trace ID", uuid)
+	}
+
+	return [16]byte(uuid), nil
+}
+
+// FromTraceID returns the UUID with the 16 bytes of a trace ID. Trace IDs not
+// created from UUIDs have no version or variant bits. Returns an error for
+// the all-zero trace ID, which is invalid.
+func FromTraceID(id [16]byte) (UUID, error) {
+	if id == [16]byte{} {
+		return nil, fmt.Errorf("uuid: invalid trace ID %x", id)
+	}
+
+	return append(UUID(nil), id[:]...), nil
+}
+
+// SpanID derives an 8 byte W3C Trace Context span ID from uuid, its last 8
+// bytes, which are random in Version 4 and 7 UUIDs, e.g. for the root span of
+// a request identified by uuid. Returns an error if those bytes are all zero,
+// which is an invalid span ID.
+func (uuid UUID) SpanID() ([8]byte, error) {
+	if len(uuid) != 16 || [8]byte(uuid[8:]) == [8]byte{} {
+		return [8]byte{}, fmt.Errorf("uuid: %s does not derive a valid span ID",
+			uuid)
+	}
+
+	return [8]byte(uuid[8:]), nil
+}
+
+// Traceparent returns the W3C Trace Context traceparent header of the trace
+// identified by uuid, with the span ID of uuid and the sampled flag, e.g.
+// 00-0190a5dbac96774bbcceb302099a8057-bcceb302099a8057-01.
+func (uuid UUID) Traceparent(sampled bool) (string, error) {
+	traceID, err := uuid.TraceID()
+	if err != nil {
+		return "", err
+	}
+	spanID, err := uuid.SpanID()
+	if err != nil {
+		return "", err
+	}
+
+	flags := "00"
+	if sampled {
+		flags = "01"
+	}
+
+	return fmt.Sprintf("00-%x-%x-%s", traceID, spanID, flags), nil
+}
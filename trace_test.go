@@ -0,0 +1,56 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTraceID(t *testing.T) {
+	in := NewV7()
+	traceID, err := in.TraceID()
+	if err != nil || !bytes.Equal(traceID[:], in) {
+		t.Errorf("Failed to convert to trace ID. Expected: %x, Received: %x",
+			[]byte(in), traceID)
+	}
+	if result, err := FromTraceID(traceID); err != nil || !result.Equal(in) {
+		t.Errorf("Failed to convert from trace ID. Expected: %s, Received: %s",
+			in, result)
+	}
+
+	if _, err := Nil.TraceID(); err == nil {
+		t.Errorf("converted the Nil UUID to a trace ID")
+	}
+	if _, err := FromTraceID([16]byte{}); err == nil {
+		t.Errorf("converted the invalid trace ID")
+	}
+}
+
+func TestSpanID(t *testing.T) {
+	in := NewV4()
+	spanID, err := in.SpanID()
+	if err != nil || !bytes.Equal(spanID[:], in[8:]) {
+		t.Errorf("Failed to derive span ID. Expected: %x, Received: %x",
+			[]byte(in[8:]), spanID)
+	}
+
+	zero := UUID{0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	if _, err := zero.SpanID(); err == nil {
+		t.Errorf("derived the invalid span ID")
+	}
+}
+
+func TestTraceparent(t *testing.T) {
+	in, _ := Parse("0190a5db-ac96-774b-bcce-b302099a8057")
+	header, err := in.Traceparent(true)
+	expected := "00-0190a5dbac96774bbcceb302099a8057-bcceb302099a8057-01"
+	if err != nil || header != expected {
+		t.Errorf("Failed to format traceparent. Expected: %s, Received: %s",
+			expected, header)
+	}
+	if header, _ := in.Traceparent(false); header[len(header)-2:] != "00" {
+		t.Errorf("Failed to clear the sampled flag: %s", header)
+	}
+	if _, err := Nil.Traceparent(true); err == nil {
+		t.Errorf("formatted a traceparent for the Nil UUID")
+	}
+}
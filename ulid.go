@@ -0,0 +1,45 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewULID generates a ULID, in its 26 character canonical form. The ULID is a
+// Version 7 UUID, whose 48-bit millisecond timestamp and random bits are laid
+// out as those of a ULID, so FromULID turns it into a valid RFC 9562 UUID and
+// ULIDs and UUIDs generated together sort in the same order. Panics if the
+// random source fails.
+func NewULID() string {
+	return Default().NewULID()
+}
+
+// NewULID generates a ULID using the clock and random source of g, see the
+// package-level NewULID.
+func (g *Generator) NewULID() string {
+	return g.NewV7().ToULID()
+}
+
+// ToULID returns the 128 bits of uuid as a ULID, 26 uppercase Crockford
+// base32 digits. The conversion is lossless, so any UUID can be stored in a
+// ULID-keyed system, but only the timestamp of time-ordered versions such as
+// Version 7 is meaningful to it. Panics if uuid is not 16 bytes long.
+func (uuid UUID) ToULID() string {
+	return strings.ToUpper(encodeCrockford(uuid.Array()))
+}
+
+// FromULID converts a ULID in upper or lower case into the UUID with the same
+// 128 bits, the inverse of ToULID. ULIDs not created from UUIDs have no
+// version or variant bits.
+func FromULID(s string) (UUID, error) {
+	array, ok := decodeCrockford(s)
+	if !ok {
+		return nil, fmt.Errorf("uuid: invalid ULID %q", s)
+	}
+
+	return array[:], nil
+}
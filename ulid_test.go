@@ -0,0 +1,62 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestULID(t *testing.T) {
+	// the example of the ULID specification, 01ARZ3NDEKTSV4RRFFQ69G5FAV,
+	// encodes the millisecond 1469922850259
+	in, err := FromULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatalf("failed to convert ULID: %v", err)
+	}
+	millis := uint64(in[0])<<40 | uint64(in[1])<<32 | uint64(in[2])<<24 |
+		uint64(in[3])<<16 | uint64(in[4])<<8 | uint64(in[5])
+	if millis != 1469922850259 {
+		t.Errorf("Failed to convert ULID timestamp. Expected: 1469922850259, "+
+			"Received: %d", millis)
+	}
+	if s := in.ToULID(); s != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("Failed to convert to ULID. Expected: "+
+			"01ARZ3NDEKTSV4RRFFQ69G5FAV, Received: %s", s)
+	}
+	if lower, _ := FromULID("01arz3ndektsv4rrffq69g5fav"); !lower.Equal(in) {
+		t.Errorf("Failed to convert lowercase ULID. Expected: %s, "+
+			"Received: %s", in, lower)
+	}
+
+	for _, s := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA",
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU"} {
+		if _, err := FromULID(s); err == nil {
+			t.Errorf("converted invalid ULID %q", s)
+		}
+	}
+}
+
+func TestNewULID(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithClock(FrozenClock(now)))
+
+	last := ""
+	for i := 0; i < 100; i++ {
+		id := g.NewULID()
+		if id <= last {
+			t.Fatalf("ULIDs not increasing: %s, %s", last, id)
+		}
+		last = id
+
+		result, err := FromULID(id)
+		if err != nil || result.Version() != Version7 {
+			t.Fatalf("ULID %s is not a version 7 UUID: %s", id, result)
+		}
+		millis := uint64(result[0])<<40 | uint64(result[1])<<32 |
+			uint64(result[2])<<24 | uint64(result[3])<<16 |
+			uint64(result[4])<<8 | uint64(result[5])
+		if millis != uint64(now.UnixMilli()) {
+			t.Fatalf("Failed to embed time in ULID. Expected: %d, "+
+				"Received: %d", now.UnixMilli(), millis)
+		}
+	}
+}
@@ -8,11 +8,11 @@ package uuid
 
 import (
 	"crypto/md5"
-	"crypto/sha1"
 	"fmt"
-	"math/rand"
-	"net"
-	"sync"
+	"io"
+	"math"
+	"runtime"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,62 +21,109 @@ import (
 // which is limited to 250ish years of nanoseconds we need to perform the
 // intermediate step of calculating 100s of nanoseconds between the Gregorian
 // epoch and Unix epoch. We determine the number of days between 15 October
-// 1582 (Julian 2299171) and 1 January 1970 (Julian 2440601) and then multiply
+// 1582 (Julian 2299161) and 1 January 1970 (Julian 2440588) and then multiply
 // the number of seconds by 1e7. Nanoseconds are 1e9 but since we are dividing
 // by 100, we only need to multiply 1e7).
 
 // Julian dates calculated from http://numerical.recipes/julian.html
 
 const (
-	gregorianEpochJulianDays = 2299171 // 15 October 1582
-	unixEpochJulianDays      = 2440601 // 1 January 1970
+	gregorianEpochJulianDays = 2299161 // 15 October 1582
+	unixEpochJulianDays      = 2440588 // 1 January 1970
 )
 
 var epochDiffNanos100s = uint64((unixEpochJulianDays - gregorianEpochJulianDays) *
 	(24 * 60 * 60) * 1e7)
 
-type uuid struct {
-	sync.Mutex
-	timestamp uint64
-	clock     uint16
-	count     uint32
-	node      []byte
-	namespace []byte
+// UUID is a 128-bit / 16 byte array representing a RFC 4122 UUID.
+type UUID []byte
+
+// Nil is the nil UUID, which has all 128 bits set to zero.
+var Nil = make(UUID, 16)
+
+// Max is the max UUID defined by RFC 9562, which has all 128 bits set to one.
+// It is useful as an upper bound when scanning ranges of UUIDs.
+var Max = UUID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+	0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+
+// randomNode returns a random 48-bit node ID with the multicast bit set, so it
+// cannot conflict with the address of a network interface (RFC 4122 section
+// 4.5). Returns an error if the random source fails.
+func (g *Generator) randomNode() ([]byte, error) {
+	node := make([]byte, 6)
+	if err := g.readRandom(node); err != nil {
+		return nil, err
+	}
+	node[0] |= 0x01
+
+	return node, nil
 }
 
-var u = uuid{
-	timestamp: getNanos100s(),
-	clock:     uint16(rand.Uint32()),
-	count:     0,
-	namespace: make([]byte, 16),
+// SetPrivacyNode selects whether Version 1, 2 and 6 UUIDs embed a random node
+// ID instead of the hardware address of a network interface, so the address
+// never leaks into identifiers. A new random node ID is chosen each time
+// privacy mode is enabled. If the random source fails the time-based
+// constructors return its error until a later draw succeeds.
+func SetPrivacyNode(enabled bool) {
+	Default().SetPrivacyNode(enabled)
 }
 
-func init() {
-	// read network interfaces
-	interfaces, err := net.Interfaces()
-	// if unable to read interfaces, set to random
-	if err != nil {
-		randomNode := make([]byte, 6)
-		// create 48-bit random bits
-		rand.Read(randomNode)
-		// check to ensure the most significant bit of the random bits is 1
-		randomNode[0] = randomNode[0] | 128
-		u.node = randomNode
-	}
-
-	// select the first six-byte network interface
-	// todo add error handling in the event only 8-byte interfaces are present
-	for _, inter := range interfaces {
-		if len(inter.HardwareAddr) != 6 {
-			continue
-		} else {
-			u.node = inter.HardwareAddr
-			break
-		}
+// SetPrivacyNode selects whether time-based UUIDs generated by g embed a
+// random node ID, see the package-level SetPrivacyNode.
+func (g *Generator) SetPrivacyNode(enabled bool) {
+	g.ensureNode()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if enabled {
+		g.node, g.nodeErr = g.randomNode()
+		g.nodeSource = NodePrivacy
+	} else {
+		g.node, g.nodeErr = g.baseNode, g.baseNodeErr
+		g.nodeSource = g.baseNodeSource
+	}
+	g.publishSequence()
+}
+
+// SetNodeID pins the node ID embedded in Version 1, 2 and 6 UUIDs, e.g. to
+// keep it stable across restarts, and disables privacy mode. Returns an error
+// if node is all zeros.
+func SetNodeID(node [6]byte) error {
+	return Default().SetNodeID(node)
+}
+
+// SetNodeID pins the node ID embedded in time-based UUIDs generated by g, see
+// the package-level SetNodeID.
+func (g *Generator) SetNodeID(node [6]byte) error {
+	if node == [6]byte{} {
+		return fmt.Errorf("uuid: invalid node ID %x", node)
 	}
+	// skip the node chain, but still disable privacy mode
+	g.acquireNodeOnce([]NodeStrategy{StaticNode(node)})
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.baseNode, g.baseNodeSource, g.baseNodeErr = node[:], NodeStatic, nil
+	g.node, g.nodeSource, g.nodeErr = g.baseNode, g.baseNodeSource, nil
+	g.publishSequence()
+
+	return nil
+}
+
+// NodeID returns the node ID currently embedded in Version 1, 2 and 6 UUIDs.
+func NodeID() [6]byte {
+	return Default().NodeID()
+}
+
+// NodeID returns the node ID currently embedded in time-based UUIDs generated
+// by g.
+func (g *Generator) NodeID() [6]byte {
+	var node [6]byte
+	copy(node[:], g.currentNode())
 
-	// generate random uuid namespace in case one's not provided
-	u.namespace = NewV4()
+	return node
 }
 
 func uint32ToBytes(val uint32) []byte {
@@ -95,17 +142,17 @@ func uint16ToBytes(val uint16) []byte {
 	return result
 }
 
-// getNanos100s calculates the 100s of nanoseconds between now(UTC) and the
-// Gregorian calendar epoch. Returns 100s of nanoseconds.
-func getNanos100s() uint64 {
-	return epochDiffNanos100s + uint64(time.Now().In(time.UTC).UnixNano()/100)
+// nanos100sAt calculates the 100s of nanoseconds between t and the Gregorian
+// calendar epoch. Returns 100s of nanoseconds.
+func nanos100sAt(t time.Time) uint64 {
+	return epochDiffNanos100s + uint64(t.In(time.UTC).UnixNano()/100)
 }
 
 func createUuidByteArray(timeLow []byte, timeMid []byte,
 	timeHighAndVersion []byte, clockSeqHi byte, clockSeqLow byte,
-	node []byte) []byte {
+	node []byte) UUID {
 
-	result := make([]byte, 0, 0)
+	result := make(UUID, 0, 16)
 	result = append(result, timeLow...)
 	result = append(result, timeMid...)
 	result = append(result, timeHighAndVersion...)
@@ -116,29 +163,280 @@ func createUuidByteArray(timeLow []byte, timeMid []byte,
 	return result
 }
 
+// casAttempts is the number of compare-and-swap races a goroutine may lose
+// generating a time-based UUID before it takes the Generator lock.
+const casAttempts = 4
+
+// v1Locked marks v1Last while the clock sequence is changed after a rollback.
+// Timestamps are 60 bits so it is never a valid value.
+const v1Locked = math.MaxUint64
+
+// v1Sequence is the clock sequence and node used with the timestamps of
+// Version 1, 2 and 6 UUIDs. It is replaced, never modified, so goroutines can
+// read it without the Generator lock.
+type v1Sequence struct {
+	clock uint16
+	node  []byte
+	// persist is set when a StableStorage is configured, which is only
+	// written with the Generator locked
+	persist bool
+	// err is the error of the random source while choosing clock or node,
+	// returned instead of timestamps
+	err error
+}
+
+// publishSequence makes the current clock sequence and node visible to
+// reserveTimestamps. Must be called with g.mu held.
+func (g *Generator) publishSequence() {
+	err := g.clockErr
+	if err == nil {
+		err = g.nodeErr
+	}
+	g.v1Seq.Store(&v1Sequence{clock: g.clock, node: g.node,
+		persist: g.state != nil, err: err})
+}
+
+// repairSequence draws the random clock sequence and node IDs again whose
+// first draw failed, and returns the error of the random source if it still
+// fails. Must be called with g.mu held.
+func (g *Generator) repairSequence() error {
+	if g.v1Seq.Load().err == nil {
+		return nil
+	}
+
+	if g.clockErr != nil {
+		clock, err := g.randomUint32()
+		if err != nil {
+			return err
+		}
+		g.setClock(uint16(clock))
+		g.clockErr = nil
+	}
+	if g.baseNodeErr != nil {
+		node, err := g.randomNode()
+		if err != nil {
+			return err
+		}
+		g.baseNode, g.baseNodeErr = node, nil
+		if g.nodeSource != NodePrivacy {
+			g.node, g.nodeErr = node, nil
+		}
+	}
+	if g.nodeErr != nil {
+		node, err := g.randomNode()
+		if err != nil {
+			return err
+		}
+		g.node, g.nodeErr = node, nil
+	}
+	g.publishSequence()
+
+	return nil
+}
+
+// nextTimestamp advances the generator state and returns the 60-bit
+// timestamp, clock sequence and node for a time-based UUID.
+func (g *Generator) nextTimestamp() (uint64, uint16, []byte, error) {
+	return g.reserveTimestamps(1)
+}
+
+// reserveTimestamps advances the generator state past n time-based UUIDs and
+// returns the first of n consecutive 60-bit timestamps, and the clock
+// sequence and node to use with all of them.
+//
+// v1Last holds the last timestamp handed out and is advanced with
+// compare-and-swap, so goroutines do not serialize on a lock. A timestamp is
+// the current time, or one past v1Last if the clock has not moved past it,
+// which simulates a high resolution clock with a count of the UUIDs generated
+// within the same system time interval. v1Tick holds the latest reading of
+// the clock, to tell a clock that moved backwards from a burst. Rollbacks,
+// stable storage, an exhausted counter, a failed random source and
+// goroutines that lose casAttempts races take the slow path under the
+// Generator lock.
+func (g *Generator) reserveTimestamps(n int) (uint64, uint16, []byte, error) {
+	g.ensureNode()
+	for attempt := 0; attempt < casAttempts; attempt++ {
+		seq := g.v1Seq.Load()
+		last := g.v1Last.Load()
+		now := g.clockSource().Ticks()
+		if seq.persist || seq.err != nil || last == v1Locked || now < g.v1Tick.Load() {
+			break
+		}
+
+		next := max(now, last+1)
+		if next+uint64(n-1) > now+v1CounterLimit {
+			break
+		}
+		if !g.v1Last.CompareAndSwap(last, next+uint64(n-1)) {
+			continue
+		}
+		// the sequence may have changed between loading it and claiming
+		// the timestamps, in which case they are discarded
+		if g.v1Seq.Load() == seq {
+			g.storeTick(now)
+			return next, seq.clock, seq.node, nil
+		}
+	}
+
+	return g.reserveTimestampsLocked(n)
+}
+
+// reserveTimestampsLocked is the slow path of reserveTimestamps.
+func (g *Generator) reserveTimestampsLocked(n int) (uint64, uint16, []byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.repairSequence(); err != nil {
+		return 0, 0, nil, err
+	}
+
+	stalled := false
+	for {
+		last := g.v1Last.Load()
+		now := g.clockSource().Ticks()
+
+		if now < g.v1Tick.Load() {
+			// The clock moved backwards, e.g. an NTP step or a resumed
+			// virtual machine, so timestamps already used may come again.
+			// RFC 4122 section 4.1.5 requires the clock sequence to change so
+			// they cannot produce duplicate UUIDs. v1Last is locked while the
+			// sequence changes, so no timestamp is claimed with the old
+			// sequence after the reset.
+			if !g.v1Last.CompareAndSwap(last, v1Locked) {
+				continue
+			}
+			g.setClock(g.clock + 1)
+			g.clockChanges.Add(1)
+			g.publishSequence()
+			g.v1Tick.Store(now)
+			g.v1Last.Store(now + uint64(n-1))
+			g.storeState(now + uint64(n-1))
+
+			return now, g.clock, g.node, nil
+		}
+
+		next := max(now, last+1)
+		if next+uint64(n-1) > now+v1CounterLimit {
+			if n > v1CounterLimit ||
+				CounterPolicy(g.counterPolicy.Load()) == CounterReturnError {
+				return 0, 0, nil, ErrCounterExhausted
+			}
+			// wait for the clock to catch up with the counter
+			if !stalled {
+				g.counterStalls.Add(1)
+				stalled = true
+			}
+			runtime.Gosched()
+			continue
+		}
+		if g.v1Last.CompareAndSwap(last, next+uint64(n-1)) {
+			g.storeTick(now)
+			g.storeState(next + uint64(n-1))
+
+			return next, g.clock, g.node, nil
+		}
+	}
+}
+
+// storeTick records now as the latest reading of the clock, unless a later
+// one has been recorded.
+func (g *Generator) storeTick(now uint64) {
+	storeMax(&g.v1Tick, now)
+}
+
+// storeMax stores val in x unless x already holds a greater value.
+func storeMax(x *atomic.Uint64, val uint64) {
+	for {
+		old := x.Load()
+		if val <= old || x.CompareAndSwap(old, val) {
+			return
+		}
+	}
+}
+
+// currentNode returns the node ID embedded in time-based UUIDs.
+func (g *Generator) currentNode() []byte {
+	g.ensureNode()
+	return g.v1Seq.Load().node
+}
+
 // NewV1 generates a RFC 4122 Version 1 compliant UUID. Returns 128-bit / 16
-// byte array representing the UUID.
-func NewV1() []byte {
+// byte array representing the UUID. Panics if the timestamp counter is
+// exhausted under CounterReturnError, see NewV1E.
+func NewV1() UUID {
+	return Default().NewV1()
+}
 
-	u.Lock()
-	newTime := getNanos100s()
+// NewV1 generates a RFC 4122 Version 1 compliant UUID using the clock
+// sequence and node of g.
+func (g *Generator) NewV1() UUID {
+	return Must(g.NewV1E())
+}
 
-	if newTime > u.timestamp {
-		u.clock++
-		u.timestamp = newTime
-		u.count = 0
-	} else {
-		// A high resolution timestamp can be simulated by keeping a count of
-		// the number of UUIDs that have been generated with the same value of
-		// the system time, and using it to construct the low order bits of the
-		// timestamp.  The count will range between zero and the number of
-		// 100-nanosecond intervals per system time interval.
-		u.count++
-		newTime += uint64(u.count)
+// NewV1E generates a RFC 4122 Version 1 compliant UUID like NewV1, but returns
+// ErrCounterExhausted instead of panicking, see CounterReturnError.
+func NewV1E() (UUID, error) {
+	return Default().NewV1E()
+}
+
+// NewV1E generates a RFC 4122 Version 1 compliant UUID using the clock
+// sequence and node of g, returning an error if the counter is exhausted.
+func (g *Generator) NewV1E() (UUID, error) {
+	newTime, clockSequence, node, err := g.nextTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	g.countGenerated(Version1, 1)
+
+	return newV1(newTime, clockSequence, node), nil
+}
+
+// NewV1At generates a Version 1 UUID for the time t instead of the current
+// time, for backfilling identifiers of historical records. The clock
+// sequence is chosen at random for each call and the generator state is left
+// untouched. Returns 128-bit / 16 byte array representing the UUID. Panics if
+// the random source fails.
+func NewV1At(t time.Time) UUID {
+	return Default().NewV1At(t)
+}
+
+// NewV1At generates a Version 1 UUID for the time t using the node of g, see
+// the package-level NewV1At.
+func (g *Generator) NewV1At(t time.Time) UUID {
+	clockSequence, node, err := g.randomSequence()
+	if err != nil {
+		panic(err)
+	}
+	g.countGenerated(Version1, 1)
+
+	return newV1(nanos100sAt(t), clockSequence, node)
+}
+
+// randomSequence returns a random clock sequence and the node of g for the
+// constructors taking an explicit time, or the error of the random source.
+func (g *Generator) randomSequence() (uint16, []byte, error) {
+	g.ensureNode()
+	seq := g.v1Seq.Load()
+	if seq.err != nil {
+		g.mu.Lock()
+		err := g.repairSequence()
+		seq = g.v1Seq.Load()
+		g.mu.Unlock()
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	clockSequence, err := g.randomUint32()
+	if err != nil {
+		return 0, nil, err
 	}
-	clockSequence := u.clock
-	u.Unlock()
 
+	return uint16(clockSequence), seq.node, nil
+}
+
+// newV1 lays out a Version 1 UUID from a 60-bit timestamp, clock sequence
+// and node.
+func newV1(newTime uint64, clockSequence uint16, node []byte) UUID {
 	timeLow := uint32(0xFFFFFFFF & newTime)
 	timeMid := uint16((newTime >> 32) & 0xFFFF)
 	timeHiAndVersion := uint16(((newTime >> 48) & 0x0FFF) | 0x1000)
@@ -148,34 +446,51 @@ func NewV1() []byte {
 	return createUuidByteArray(uint32ToBytes(timeLow),
 		uint16ToBytes(timeMid),
 		uint16ToBytes(timeHiAndVersion), byte(clockSeqHiAndReserved),
-		byte(clockSeqLow), u.node)
+		byte(clockSeqLow), node)
 }
 
 // NewV3 generates a RFC 4122 Version 3 compliant UUID. Parameters are 128-bit
 // namespace UUID and hostname. Returns 128-bit / 16 byte array representing
-// the UUID.
-func NewV3(namespaceUUID []byte, name string) []byte {
-
-	concatName := append(namespaceUUID, []byte(name)...)
-	md5hash := md5.Sum(concatName)
-	timeLow := md5hash[0:4]
-	timeMid := md5hash[4:6]
-	timeHighAndVersion := md5hash[6:8]
-	timeHighAndVersion[0] &= 0x0F
-	timeHighAndVersion[0] |= 0x30
-	clockSeqHigh := md5hash[8]
-	clockSeqHigh &= 0x3F
-	clockSeqHigh |= 0x80
-	clockSeqLow := md5hash[9]
-	node := md5hash[10:]
-
-	return createUuidByteArray(timeLow, timeMid, timeHighAndVersion,
-		clockSeqHigh, clockSeqLow, node)
+// the UUID. Panics with ErrMD5Disabled in FIPS mode, see NewV3E.
+func NewV3(namespaceUUID UUID, name string) UUID {
+	return Must(NewV3E(namespaceUUID, name))
+}
+
+// NewV3E generates a RFC 4122 Version 3 compliant UUID like NewV3, but returns
+// ErrMD5Disabled instead of panicking in FIPS mode, see SetFIPSMode.
+func NewV3E(namespaceUUID UUID, name string) (UUID, error) {
+	if FIPSMode() {
+		return nil, ErrMD5Disabled
+	}
+
+	hash := newNameHash(md5.New(), Version3, namespaceUUID)
+	io.WriteString(hash, name)
+
+	return hash.UUID(), nil
 }
 
 // NewV4 generates a RFC 4122 Version 4 compliant UUID. Returns 128-bit / 16
-// byte array representing the UUID.
-func NewV4() []byte {
+// byte array representing the UUID. Panics if the random source fails, see
+// NewV4E.
+func NewV4() UUID {
+	return Default().NewV4()
+}
+
+// NewV4E generates a RFC 4122 Version 4 compliant UUID like NewV4, but returns
+// an error instead of panicking if the random source fails.
+func NewV4E() (UUID, error) {
+	return Default().NewV4E()
+}
+
+// NewV4 generates a RFC 4122 Version 4 compliant UUID using the random source
+// of g. Panics if the random source fails, see NewV4E.
+func (g *Generator) NewV4() UUID {
+	return Must(g.NewV4E())
+}
+
+// NewV4E generates a RFC 4122 Version 4 compliant UUID using the random source
+// of g, returning an error if the random source fails.
+func (g *Generator) NewV4E() (UUID, error) {
 	/*
 		1. Set all the other bits to randomly (or pseudo-randomly) chosen
 		values.
@@ -187,44 +502,72 @@ func NewV4() []byte {
 		time_hi_and_version field to the 4-bit version number
 	*/
 
-	result := make([]byte, 16)
-	rand.Read(result)                     // step 1
+	if result, ok := g.backendV4(); ok {
+		g.countGenerated(Version4, 1)
+		return result, nil
+	}
+
+	result := make(UUID, 16)
+	if err := g.readRandomPooled(result); err != nil { // step 1
+		return nil, err
+	}
 	result[8] = (result[8] & 0x3F) | 0x80 // step 2
 	result[6] = (result[6] & 0x0F) | 0x40 // step 3
+	g.countGenerated(Version4, 1)
 
-	return result
+	return result, nil
 }
 
-func NewV5(namespaceUUID []byte, name string) []byte {
-	concatName := append(namespaceUUID, []byte(name)...)
-	sha1Hash := sha1.Sum(concatName) // returns a 20-byte (160 bit) array
-	sha1HashReduced := sha1Hash[0:16] // need a 16-byte (128 bit) array
-	timeLow := sha1HashReduced[0:4]
-	timeMid := sha1HashReduced[4:6]
-	timeHighAndVersion := sha1HashReduced[6:8]
-	timeHighAndVersion[0] &= 0x0F
-	timeHighAndVersion[0] |= 0x50
-	clockSeqHigh := sha1HashReduced[8]
-	clockSeqHigh &= 0x3F
-	clockSeqHigh |= 0x80
-	clockSeqLow := sha1HashReduced[9]
-	node := sha1HashReduced[10:16]
-
-	return createUuidByteArray(timeLow, timeMid, timeHighAndVersion,
-		clockSeqHigh, clockSeqLow, node)
+// NewV5 generates a RFC 4122 Version 5 compliant UUID. Parameters are 128-bit
+// namespace UUID and name. Returns 128-bit / 16 byte array representing the
+// UUID.
+func NewV5(namespaceUUID UUID, name string) UUID {
+	hash := NewV5Hash(namespaceUUID)
+	io.WriteString(hash, name)
 
+	return hash.UUID()
 }
 
-//PrintUUID returns properly formatted UUID string for any RFC 4122 version,
-//including the nil UUID.
+// PrintUUID returns properly formatted UUID string for any RFC 4122 version,
+// including the nil UUID. A uuid that is not 16 bytes long is rendered as
+// invalid-uuid(...) around its raw hex digits.
 func PrintUUID(uuid []byte) string {
 	if uuid == nil {
-		uuid = make([]byte, 16)
+		uuid = Nil
+	}
+	if len(uuid) != 16 {
+		return invalidString(uuid)
 	}
 
-	return fmt.Sprintf("%0.8x-%0.4x-%0.4x-%0.2x%0.2x-%0.12x",
-		uuid[0:4], uuid[4:6], uuid[6:8],
-		uuid[8], uuid[9], uuid[10:16])
+	var buf [36]byte
+	return string(appendHex(buf[:0], uuid, lowerHexDigits, true))
 }
 
-// todo add uuid string to byte array conversion
+// IsNil reports whether uuid is the nil UUID. A nil or empty UUID is also
+// treated as the nil UUID, a UUID of any other length than 16 bytes is not.
+func (uuid UUID) IsNil() bool {
+	if len(uuid) != 0 && len(uuid) != 16 {
+		return false
+	}
+	for _, b := range uuid {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsMax reports whether uuid is the max UUID.
+func (uuid UUID) IsMax() bool {
+	if len(uuid) != 16 {
+		return false
+	}
+	for _, b := range uuid {
+		if b != 0xFF {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,7 +1,11 @@
 package uuid
 
 import (
+	"bytes"
+	"errors"
 	"testing"
+	"testing/iotest"
+	"time"
 )
 
 const NilUUID = "00000000-0000-0000-0000-000000000000"
@@ -25,6 +29,34 @@ func TestPrintUUID(t *testing.T) {
 	}
 }
 
+func TestIsNil(t *testing.T) {
+	if !Nil.IsNil() || !UUID(nil).IsNil() || !(UUID{}).IsNil() {
+		t.Errorf("failed to detect the nil UUID")
+	}
+	if (UUID{0, 0}).IsNil() {
+		t.Errorf("reported a 2 byte UUID as nil")
+	}
+	if PrintUUID(Nil) != NilUUID {
+		t.Errorf("Failed to print the Nil UUID. Expected: %s, "+
+			"Received: %s", NilUUID, PrintUUID(Nil))
+	}
+	if NewV4().IsNil() {
+		t.Errorf("reported a version 4 UUID as nil")
+	}
+}
+
+func TestIsMax(t *testing.T) {
+	if !Max.IsMax() {
+		t.Errorf("failed to detect the max UUID")
+	}
+	if PrintUUID(Max) != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Errorf("incorrect max UUID: %s", PrintUUID(Max))
+	}
+	if Nil.IsMax() || UUID(nil).IsMax() || NewV4().IsMax() {
+		t.Errorf("reported a non-max UUID as max")
+	}
+}
+
 func TestNewV1(t *testing.T) {
 	result := NewV1()
 	if result == nil {
@@ -47,8 +79,21 @@ func TestNewV1(t *testing.T) {
 	}
 }
 
+func TestNewV1At(t *testing.T) {
+	// the timestamp from RFC 9562 appendix A.1
+	at := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	result := NewV1At(at)
+	if s := PrintUUID(result); s[:18] != "c232ab00-9414-11ec" {
+		t.Errorf("Failed to embed timestamp. Expected: c232ab00-9414-11ec..., "+
+			"Received: %s", s)
+	}
+	if result[8]>>6 != 2 {
+		t.Fatalf("incorrect clock sequence detected")
+	}
+}
+
 func TestNewV3(t *testing.T) {
-	result := NewV3(u.namespace, "test")
+	result := NewV3(Namespace(), "test")
 	if result == nil {
 		t.Fatalf("returned a nil byte array")
 	}
@@ -65,7 +110,7 @@ func TestNewV3(t *testing.T) {
 
 	// check string properly formatted for UUID
 	for i := 0; i < 10; i++ {
-		t.Log(PrintUUID(NewV3(u.namespace, "test")))
+		t.Log(PrintUUID(NewV3(Namespace(), "test")))
 	}
 }
 
@@ -92,7 +137,7 @@ func TestNewV4(t *testing.T) {
 }
 
 func TestNewV5(t *testing.T) {
-	result := NewV5(u.namespace, "test")
+	result := NewV5(Namespace(), "test")
 	if result == nil {
 		t.Fatalf("returned a nil byte array")
 	}
@@ -109,7 +154,7 @@ func TestNewV5(t *testing.T) {
 
 	// check string properly formatted for UUID
 	for i := 0; i < 10; i++ {
-		t.Log(PrintUUID(NewV5(u.namespace, "test")))
+		t.Log(PrintUUID(NewV5(Namespace(), "test")))
 	}
 }
 
@@ -121,7 +166,7 @@ func BenchmarkNewV1(b *testing.B) {
 
 func BenchmarkNewV3(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		NewV3(u.namespace, "test")
+		NewV3(Namespace(), "test")
 	}
 }
 
@@ -133,7 +178,7 @@ func BenchmarkNewV4(b *testing.B) {
 
 func BenchmarkNewV5(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		NewV5(u.namespace, "test")
+		NewV5(Namespace(), "test")
 	}
 }
 
@@ -142,3 +187,146 @@ func BenchmarkPrintUUID(b *testing.B) {
 		PrintUUID(NewV1())
 	}
 }
+
+func TestEpochDiff(t *testing.T) {
+	// RFC 4122 section 4.1.4 offset between the Gregorian and Unix epochs
+	if epochDiffNanos100s != 0x01B21DD213814000 {
+		t.Errorf("incorrect epoch offset. Expected: %d, Received: %d",
+			uint64(0x01B21DD213814000), epochDiffNanos100s)
+	}
+}
+
+func TestSetNodeID(t *testing.T) {
+	g := NewGenerator()
+	node := [6]byte{0x02, 0x00, 0x5E, 0x10, 0x00, 0x01}
+	if err := g.SetNodeID(node); err != nil {
+		t.Fatalf("failed to set node ID: %v", err)
+	}
+	if g.NodeID() != node {
+		t.Errorf("Failed to set node ID. Expected: %x, Received: %x", node,
+			g.NodeID())
+	}
+	if result := g.NewV1(); !bytes.Equal(result[10:], node[:]) {
+		t.Errorf("Failed to embed node ID. Expected: %x, Received: %x", node,
+			result[10:])
+	}
+
+	if NodeID() != [6]byte(u.node) {
+		t.Errorf("Failed to report default node ID. Expected: %x, "+
+			"Received: %x", u.node, NodeID())
+	}
+	if err := SetNodeID([6]byte{}); err == nil {
+		t.Errorf("accepted an all-zero node ID")
+	}
+}
+
+func TestSetPrivacyNode(t *testing.T) {
+	SetPrivacyNode(true)
+	defer SetPrivacyNode(false)
+
+	for _, result := range []UUID{NewV1(), NewV2(DomainPerson, 0), NewV6()} {
+		if !bytes.Equal(result[10:], u.node) {
+			t.Errorf("Failed to embed random node. Expected: %x, "+
+				"Received: %x", u.node, result[10:])
+		}
+		// check the multicast bit is set
+		if result[10]&0x01 != 1 {
+			t.Errorf("random node without multicast bit: %x", result[10:])
+		}
+	}
+
+	SetPrivacyNode(false)
+	if result := NewV1(); !bytes.Equal(result[10:], u.baseNode) {
+		t.Errorf("Failed to restore hardware node. Expected: %x, "+
+			"Received: %x", u.baseNode, result[10:])
+	}
+}
+
+func TestNewV1RandomFailure(t *testing.T) {
+	failing := iotest.ErrReader(errors.New("entropy unavailable"))
+	g := NewGenerator(WithRand(failing), WithPrivacyNode())
+
+	if result, err := g.NewV1E(); err == nil {
+		t.Errorf("ignored a failed random source, Received: %s", result)
+	}
+	if _, err := g.NewV6E(); err == nil {
+		t.Errorf("ignored a failed random source for version 6")
+	}
+	if _, err := g.NewV2E(DomainPerson, 1000); err == nil {
+		t.Errorf("ignored a failed random source for version 2")
+	}
+	for _, at := range []func(time.Time) UUID{g.NewV1At, g.NewV6At, g.NewV7At} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("failed to panic on a failed random source")
+				}
+			}()
+			at(time.Now())
+		}()
+	}
+
+	// the clock sequence and node are drawn again once the source recovers
+	g.SetRand(nil)
+	result, err := g.NewV1E()
+	if err != nil {
+		t.Fatalf("failed to recover from a failed random source: %v", err)
+	}
+	if node := result[10:]; node[0]&0x01 != 1 || bytes.Equal(node,
+		[]byte{0x01, 0, 0, 0, 0, 0}) {
+		t.Errorf("incorrect random node detected: %x", node)
+	}
+}
+
+func TestClockRollback(t *testing.T) {
+	clock := &tickClock{ticks: nanos100sAt(seededEpoch)}
+	g := NewGenerator(WithClock(clock))
+
+	clock.ticks += 1000
+	before := g.NewV1()
+	clock.ticks -= 500
+	after := g.NewV1()
+
+	if v1Ticks(after) != clock.ticks {
+		t.Errorf("Failed to use rolled back clock. Expected: %d, Received: %d",
+			clock.ticks, v1Ticks(after))
+	}
+	if v1ClockSequence(after) != (v1ClockSequence(before)+1)&0x3FFF {
+		t.Errorf("Failed to change clock sequence on rollback. Expected: %d, "+
+			"Received: %d", v1ClockSequence(before)+1, v1ClockSequence(after))
+	}
+}
+
+func TestNewV1Concurrent(t *testing.T) {
+	const goroutines, perGoroutine = 8, 2000
+
+	g := NewGenerator()
+	results := make(chan []UUID, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			list := make([]UUID, perGoroutine)
+			for j := range list {
+				list[j] = g.NewV1()
+			}
+			results <- list
+		}()
+	}
+
+	seen := make(map[string]bool, goroutines*perGoroutine)
+	for i := 0; i < goroutines; i++ {
+		for _, result := range <-results {
+			if seen[result.String()] {
+				t.Fatalf("Duplicate UUIDs detected: %s", result)
+			}
+			seen[result.String()] = true
+		}
+	}
+}
+
+func BenchmarkNewV1Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			NewV1()
+		}
+	})
+}
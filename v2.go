@@ -0,0 +1,87 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// reference https://pubs.opengroup.org/onlinepubs/9696989899/chap5.htm
+
+import (
+	"fmt"
+)
+
+// Domain is a DCE Security local domain, stored in the clock_seq_low field
+// of a Version 2 UUID.
+type Domain byte
+
+const (
+	DomainPerson Domain = 0 // POSIX UID
+	DomainGroup  Domain = 1 // POSIX GID
+	DomainOrg    Domain = 2 // organization defined
+)
+
+func (d Domain) String() string {
+	switch d {
+	case DomainPerson:
+		return "Person"
+	case DomainGroup:
+		return "Group"
+	case DomainOrg:
+		return "Org"
+	}
+
+	return fmt.Sprintf("Domain%d", byte(d))
+}
+
+// NewV2 generates a DCE Security Version 2 UUID. The time_low field of a
+// Version 1 UUID is replaced by the local identifier id (e.g. a POSIX UID or
+// GID) and the clock_seq_low field is replaced by domain. Returns 128-bit /
+// 16 byte array representing the UUID. Panics if the timestamp counter is
+// exhausted under CounterReturnError, see NewV2E.
+func NewV2(domain Domain, id uint32) UUID {
+	return Default().NewV2(domain, id)
+}
+
+// NewV2 generates a DCE Security Version 2 UUID using the clock sequence and
+// node of g.
+func (g *Generator) NewV2(domain Domain, id uint32) UUID {
+	return Must(g.NewV2E(domain, id))
+}
+
+// NewV2E generates a DCE Security Version 2 UUID like NewV2, but returns
+// ErrCounterExhausted instead of panicking, see CounterReturnError.
+func NewV2E(domain Domain, id uint32) (UUID, error) {
+	return Default().NewV2E(domain, id)
+}
+
+// NewV2E generates a DCE Security Version 2 UUID using the clock sequence and
+// node of g, returning an error if the counter is exhausted.
+func (g *Generator) NewV2E(domain Domain, id uint32) (UUID, error) {
+	newTime, clockSequence, node, err := g.nextTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	g.countGenerated(Version2, 1)
+
+	timeMid := uint16((newTime >> 32) & 0xFFFF)
+	timeHiAndVersion := uint16(((newTime >> 48) & 0x0FFF) | 0x2000)
+	clockSeqHiAndReserved := uint8((clockSequence >> 8 & 0x3F) | 0x80)
+
+	return createUuidByteArray(uint32ToBytes(id),
+		uint16ToBytes(timeMid),
+		uint16ToBytes(timeHiAndVersion), byte(clockSeqHiAndReserved),
+		byte(domain), node), nil
+}
+
+// Domain returns the local domain of a Version 2 UUID. The result is only
+// meaningful when uuid.Version() is Version2.
+func (uuid UUID) Domain() Domain {
+	return Domain(uuid[9])
+}
+
+// ID returns the local identifier of a Version 2 UUID. The result is only
+// meaningful when uuid.Version() is Version2.
+func (uuid UUID) ID() uint32 {
+	return uint32(uuid[0])<<24 | uint32(uuid[1])<<16 | uint32(uuid[2])<<8 |
+		uint32(uuid[3])
+}
@@ -0,0 +1,31 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestNewV2(t *testing.T) {
+	result := NewV2(DomainGroup, 1000)
+	if result == nil {
+		t.Fatalf("returned a nil byte array")
+	}
+
+	// check version id is 2
+	if result[6]>>4 != 2 {
+		t.Fatalf("incorrect version number detected")
+	}
+
+	// check clock bits set correctly
+	if result[8]>>6 != 2 {
+		t.Fatalf("incorrect clock sequence detected")
+	}
+
+	if result.Domain() != DomainGroup {
+		t.Errorf("Failed to embed domain. Expected: %s, Received: %s",
+			DomainGroup, result.Domain())
+	}
+	if result.ID() != 1000 {
+		t.Errorf("Failed to embed id. Expected: %d, Received: %d", 1000,
+			result.ID())
+	}
+}
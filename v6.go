@@ -0,0 +1,121 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// reference https://www.rfc-editor.org/rfc/rfc9562#section-5.6
+
+import (
+	"time"
+)
+
+// NewV6 generates a RFC 9562 Version 6 compliant UUID. Version 6 carries the
+// same timestamp, clock sequence and node as Version 1, but stores the
+// timestamp from most to least significant bits so that the byte order of
+// the UUIDs matches their creation order. Returns 128-bit / 16 byte array
+// representing the UUID. Panics if the timestamp counter is exhausted under
+// CounterReturnError, see NewV6E.
+func NewV6() UUID {
+	return Default().NewV6()
+}
+
+// NewV6 generates a RFC 9562 Version 6 compliant UUID using the clock
+// sequence and node of g.
+func (g *Generator) NewV6() UUID {
+	return Must(g.NewV6E())
+}
+
+// NewV6E generates a RFC 9562 Version 6 compliant UUID like NewV6, but returns
+// ErrCounterExhausted instead of panicking, see CounterReturnError.
+func NewV6E() (UUID, error) {
+	return Default().NewV6E()
+}
+
+// NewV6E generates a RFC 9562 Version 6 compliant UUID using the clock
+// sequence and node of g, returning an error if the counter is exhausted.
+func (g *Generator) NewV6E() (UUID, error) {
+	newTime, clockSequence, node, err := g.nextTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	g.countGenerated(Version6, 1)
+
+	return newV6(newTime, clockSequence, node), nil
+}
+
+// NewV6At generates a Version 6 UUID for the time t instead of the current
+// time, for backfilling identifiers of historical records. The clock
+// sequence is chosen at random for each call and the generator state is left
+// untouched. Returns 128-bit / 16 byte array representing the UUID. Panics if
+// the random source fails.
+func NewV6At(t time.Time) UUID {
+	return Default().NewV6At(t)
+}
+
+// NewV6At generates a Version 6 UUID for the time t using the node of g, see
+// the package-level NewV6At.
+func (g *Generator) NewV6At(t time.Time) UUID {
+	clockSequence, node, err := g.randomSequence()
+	if err != nil {
+		panic(err)
+	}
+	g.countGenerated(Version6, 1)
+
+	return newV6(nanos100sAt(t), clockSequence, node)
+}
+
+// newV6 lays out a Version 6 UUID from a 60-bit timestamp, clock sequence
+// and node.
+func newV6(newTime uint64, clockSequence uint16, node []byte) UUID {
+	timeHigh := uint32(newTime >> 28)
+	timeMid := uint16((newTime >> 12) & 0xFFFF)
+	timeLowAndVersion := uint16((newTime & 0x0FFF) | 0x6000)
+	clockSeqHiAndReserved := uint8((clockSequence >> 8 & 0x3F) | 0x80)
+	clockSeqLow := uint8(clockSequence & 0xFF)
+
+	return createUuidByteArray(uint32ToBytes(timeHigh),
+		uint16ToBytes(timeMid),
+		uint16ToBytes(timeLowAndVersion), byte(clockSeqHiAndReserved),
+		byte(clockSeqLow), node)
+}
+
+// V1ToV6 converts a Version 1 UUID into the Version 6 UUID with the same
+// timestamp, clock sequence and node. Returns nil if uuid is not a Version 1
+// UUID.
+func V1ToV6(uuid UUID) UUID {
+	if uuid.Version() != Version1 {
+		return nil
+	}
+
+	timestamp := v1Timestamp([16]byte(uuid))
+	result := make(UUID, 16)
+	copy(result, uuid)
+	copy(result[0:4], uint32ToBytes(uint32(timestamp>>28)))
+	copy(result[4:6], uint16ToBytes(uint16((timestamp>>12)&0xFFFF)))
+	copy(result[6:8], uint16ToBytes(uint16((timestamp&0x0FFF)|0x6000)))
+
+	return result
+}
+
+// V6ToV1 converts a Version 6 UUID into the Version 1 UUID with the same
+// timestamp, clock sequence and node. Returns nil if uuid is not a Version 6
+// UUID.
+func V6ToV1(uuid UUID) UUID {
+	if uuid.Version() != Version6 {
+		return nil
+	}
+
+	timestamp := uint64(uuid[0])<<52 | uint64(uuid[1])<<44 |
+		uint64(uuid[2])<<36 | uint64(uuid[3])<<28 |
+		uint64(uuid[4])<<20 | uint64(uuid[5])<<12 |
+		uint64(uuid[6]&0x0F)<<8 | uint64(uuid[7])
+
+	result := make(UUID, 16)
+	copy(result, uuid)
+	copy(result[0:4], uint32ToBytes(uint32(timestamp&0xFFFFFFFF)))
+	copy(result[4:6], uint16ToBytes(uint16((timestamp>>32)&0xFFFF)))
+	copy(result[6:8], uint16ToBytes(uint16(((timestamp>>48)&0x0FFF)|0x1000)))
+
+	return result
+}
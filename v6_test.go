@@ -0,0 +1,79 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewV6(t *testing.T) {
+	result := NewV6()
+	if result == nil {
+		t.Fatalf("returned a nil byte array")
+	}
+
+	// check version id is 6
+	if result[6]>>4 != 6 {
+		t.Fatalf("incorrect version number detected")
+	}
+
+	// check clock bits set correctly
+	if result[8]>>6 != 2 {
+		t.Fatalf("incorrect clock sequence detected")
+	}
+
+	// check the timestamp bytes sort in creation order
+	last := NewV6()
+	for i := 0; i < 10000; i++ {
+		next := NewV6()
+		if Compare(last[:8], next[:8]) > 0 {
+			t.Fatalf("timestamp out of order on test %d: %s > %s", i,
+				last, next)
+		}
+		last = next
+	}
+}
+
+func BenchmarkNewV6(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewV6()
+	}
+}
+
+func TestV1ToV6(t *testing.T) {
+	// test vectors from RFC 9562 appendix A.1 and A.5
+	v1, _ := Parse("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6, _ := Parse("1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+
+	if result := V1ToV6(v1); !result.Equal(v6) {
+		t.Errorf("Failed to convert V1 to V6. Expected: %s, Received: %s",
+			v6, result)
+	}
+	if result := V6ToV1(v6); !result.Equal(v1) {
+		t.Errorf("Failed to convert V6 to V1. Expected: %s, Received: %s",
+			v1, result)
+	}
+
+	// round trip a freshly generated UUID
+	result := NewV1()
+	if roundTrip := V6ToV1(V1ToV6(result)); !roundTrip.Equal(result) {
+		t.Errorf("Failed to round trip V1. Expected: %s, Received: %s",
+			result, roundTrip)
+	}
+
+	if V1ToV6(NewV4()) != nil || V6ToV1(NewV4()) != nil {
+		t.Errorf("converted a UUID of the wrong version")
+	}
+}
+
+func TestNewV6At(t *testing.T) {
+	// the timestamp from RFC 9562 appendix A.5
+	at := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	result := NewV6At(at)
+	if s := PrintUUID(result); s[:18] != "1ec9414c-232a-6b00" {
+		t.Errorf("Failed to embed timestamp. Expected: 1ec9414c-232a-6b00..., "+
+			"Received: %s", s)
+	}
+	if result[8]>>6 != 2 {
+		t.Fatalf("incorrect clock sequence detected")
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// reference https://www.rfc-editor.org/rfc/rfc9562#section-5.7
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// V7Precision selects how the 12-bit rand_a field of Version 7 UUIDs is
+// filled.
+type V7Precision int
+
+const (
+	// PrecisionMillisecond uses rand_a as a counter seeded with random bits
+	// each millisecond (RFC 9562 section 6.2, method 1). This is the default.
+	PrecisionMillisecond V7Precision = iota
+	// PrecisionSubMillisecond fills rand_a with the fraction of the current
+	// millisecond, giving about 244ns of resolution (RFC 9562 section 6.2,
+	// method 3).
+	PrecisionSubMillisecond
+	// Precision250Microsecond fills the top 2 bits of rand_a with the
+	// quarter of the current millisecond and uses the remaining 10 bits as
+	// a counter seeded with random bits, combining methods 1 and 3.
+	Precision250Microsecond
+	// PrecisionMicrosecond fills the top 10 bits of rand_a with the fraction
+	// of the current millisecond, giving about 977ns of resolution, and uses
+	// the remaining 2 bits as a counter.
+	PrecisionMicrosecond
+)
+
+// fractionBits returns the number of bits of rand_a holding the fraction of
+// the current millisecond. The remaining low bits are a counter.
+func (precision V7Precision) fractionBits() uint {
+	switch precision {
+	case PrecisionSubMillisecond:
+		return 12
+	case Precision250Microsecond:
+		return 2
+	case PrecisionMicrosecond:
+		return 10
+	}
+
+	return 0
+}
+
+// SetV7Precision sets how rand_a is filled for subsequent Version 7 UUIDs.
+// Ordering is preserved across a change of precision.
+func SetV7Precision(precision V7Precision) {
+	Default().SetV7Precision(precision)
+}
+
+// SetV7Precision sets how rand_a is filled for Version 7 UUIDs generated by
+// g.
+func (g *Generator) SetV7Precision(precision V7Precision) {
+	g.v7Precision.Store(int32(precision))
+}
+
+// processV7Last is the Version 7 state shared by the Generators in
+// process-wide monotonic mode, see SetV7Monotonic.
+var processV7Last atomic.Uint64
+
+// SetV7Monotonic selects whether the Version 7 UUIDs of the package-level
+// functions are ordered with those of every other Generator in the same
+// mode, so each UUID issued by the process in this mode is strictly greater
+// than the one issued before it, across goroutines, generators and
+// same-millisecond bursts. Within a single Generator Version 7 UUIDs are
+// always strictly increasing. NewV7At is not affected.
+func SetV7Monotonic(enabled bool) {
+	Default().SetV7Monotonic(enabled)
+}
+
+// SetV7Monotonic selects whether the Version 7 UUIDs generated by g are
+// ordered with those of every other Generator in process-wide monotonic
+// mode, see the package-level SetV7Monotonic.
+func (g *Generator) SetV7Monotonic(enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// carry the state over so UUIDs of g stay ordered across the change
+	if enabled {
+		storeMax(&processV7Last, g.v7Last.Load())
+	} else {
+		storeMax(&g.v7Last, processV7Last.Load())
+	}
+	g.v7Shared.Store(enabled)
+}
+
+// v7State returns the Version 7 state used by g.
+func (g *Generator) v7State() *atomic.Uint64 {
+	if g.v7Shared.Load() {
+		return &processV7Last
+	}
+
+	return &g.v7Last
+}
+
+// NewV7 generates a RFC 9562 Version 7 compliant UUID. The first 48 bits hold
+// the milliseconds since the Unix epoch, the 12-bit rand_a field holds a
+// counter or sub-millisecond time as selected by SetV7Precision, and the
+// remaining bits, apart from the version and variant, are random. UUIDs
+// generated by the process are strictly increasing. Returns 128-bit / 16
+// byte array representing the UUID. Panics if the random source fails, see
+// NewV7E.
+func NewV7() UUID {
+	return Default().NewV7()
+}
+
+// NewV7E generates a RFC 9562 Version 7 compliant UUID like NewV7, but returns
+// an error instead of panicking if the random source fails.
+func NewV7E() (UUID, error) {
+	return Default().NewV7E()
+}
+
+// NewV7 generates a RFC 9562 Version 7 compliant UUID using the clock and
+// random source of g. Panics if the random source fails, see NewV7E.
+func (g *Generator) NewV7() UUID {
+	return Must(g.NewV7E())
+}
+
+// NewV7E generates a RFC 9562 Version 7 compliant UUID using the clock and
+// random source of g, returning an error if the random source fails.
+func (g *Generator) NewV7E() (UUID, error) {
+	timestamp, err := g.reserveV7(1)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.newV7(timestamp)
+}
+
+// NewV7Batch generates n strictly increasing Version 7 UUIDs under a single
+// lock acquisition and clock read, for bulk inserts. Returns nil if n is not
+// positive. Panics if the random source fails.
+func NewV7Batch(n int) []UUID {
+	return mustBatch(Default().AppendBatch(nil, Version7, n))
+}
+
+// NewV7At generates a Version 7 UUID for the time t instead of the current
+// time, for backfilling identifiers of historical records. rand_a is filled
+// with random bits and the generator state is left untouched, so UUIDs for
+// the same millisecond are not ordered amongst themselves. Returns 128-bit /
+// 16 byte array representing the UUID. Panics if the random source fails.
+func NewV7At(t time.Time) UUID {
+	return Default().NewV7At(t)
+}
+
+// NewV7At generates a Version 7 UUID for the time t using the random source
+// of g, see the package-level NewV7At.
+func (g *Generator) NewV7At(t time.Time) UUID {
+	randA, err := g.randomUint32()
+	if err != nil {
+		panic(err)
+	}
+
+	return Must(g.newV7(uint64(t.UnixMilli())<<12 | uint64(randA&0x0FFF)))
+}
+
+// newV7 lays out a Version 7 UUID from a 60-bit timestamp, see reserveV7.
+func (g *Generator) newV7(timestamp uint64) (UUID, error) {
+	result := make(UUID, 16)
+	if err := g.readRandom(result[8:]); err != nil {
+		return nil, err
+	}
+	putV7Timestamp(result, timestamp)
+	result[8] = (result[8] & 0x3F) | 0x80 // RFC 4122 variant
+	g.countGenerated(Version7, 1)
+
+	return result, nil
+}
+
+// reserveV7 advances the Version 7 state past n UUIDs and returns the first of
+// n consecutive 60-bit timestamps, the 48-bit unix_ts_ms followed by the
+// 12-bit rand_a, or the error of the random source seeding the counter.
+//
+// The high bits of rand_a hold the fraction of the millisecond selected by the
+// precision and the low bits a counter. Each time the time advances the
+// counter is seeded with random bits leaving its most significant bit clear,
+// so there is room to count before it rolls over. Whenever the candidate
+// timestamp does not advance past the previous one, because of a burst
+// within the same interval, a counter rollover or the wall clock moving
+// backwards, the previous timestamp is incremented instead so ordering is
+// preserved.
+//
+// The state is a single word updated with compare-and-swap, so goroutines do
+// not serialize on a lock. After casAttempts lost races a goroutine takes the
+// Generator lock, so heavily contended callers queue instead of spinning.
+func (g *Generator) reserveV7(n int) (uint64, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt == casAttempts {
+			g.mu.Lock()
+			defer g.mu.Unlock()
+		}
+
+		state := g.v7State()
+		last := state.Load()
+		now := g.clockSource().Now()
+
+		bits := V7Precision(g.v7Precision.Load()).fractionBits()
+		counterBits := 12 - bits
+		fraction := uint64(now.Nanosecond()%1e6) << bits / 1e6
+		candidate := uint64(now.UnixMilli())<<12 | fraction<<counterBits
+
+		var next uint64
+		if candidate>>counterBits > last>>counterBits {
+			next = candidate
+			if counterBits > 0 {
+				seed, err := g.randomUint32()
+				if err != nil {
+					return 0, err
+				}
+				next |= uint64(seed) & (1<<(counterBits-1) - 1)
+			}
+		}
+
+		if next <= last {
+			next = last + 1
+		}
+		if state.CompareAndSwap(last, next+uint64(n-1)) {
+			return next, nil
+		}
+	}
+}
+
+// putV7Timestamp stores the 48-bit unix_ts_ms, the version and the 12-bit
+// rand_a from timestamp in the first eight bytes of uuid.
+func putV7Timestamp(uuid UUID, timestamp uint64) {
+	millis := timestamp >> 12
+	uuid[0] = byte(millis >> 40)
+	uuid[1] = byte(millis >> 32)
+	uuid[2] = byte(millis >> 24)
+	uuid[3] = byte(millis >> 16)
+	uuid[4] = byte(millis >> 8)
+	uuid[5] = byte(millis)
+	uuid[6] = byte(timestamp>>8)&0x0F | 0x70 // version 7
+	uuid[7] = byte(timestamp)
+}
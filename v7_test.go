@@ -0,0 +1,191 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewV7(t *testing.T) {
+	before := uint64(time.Now().UnixMilli())
+	result := NewV7()
+	after := uint64(time.Now().UnixMilli())
+	if result == nil {
+		t.Fatalf("returned a nil byte array")
+	}
+
+	// check version is 7
+	if result[6]>>4 != 7 {
+		t.Fatalf("incorrect version number detected")
+	}
+
+	// check clock sequence bits set correctly
+	if result[8]>>6 != 2 {
+		t.Fatalf("incorrect clock sequence detected")
+	}
+
+	// check the timestamp is the current Unix time in milliseconds
+	millis := uint64(result[0])<<40 | uint64(result[1])<<32 |
+		uint64(result[2])<<24 | uint64(result[3])<<16 |
+		uint64(result[4])<<8 | uint64(result[5])
+	// the counter may carry the timestamp at most a millisecond ahead
+	if millis < before || millis > after+1 {
+		t.Fatalf("incorrect timestamp detected. Expected: %d-%d, "+
+			"Received: %d", before, after, millis)
+	}
+
+	// check string properly formatted for UUID
+	for i := 0; i < 10; i++ {
+		t.Log(PrintUUID(NewV7()))
+	}
+}
+
+func TestNewV7Monotonic(t *testing.T) {
+	last := NewV7()
+	for i := 0; i < 100000; i++ {
+		next := NewV7()
+		if Compare(last, next) >= 0 {
+			t.Fatalf("UUID out of order on test %d: %s >= %s", i, last,
+				next)
+		}
+		last = next
+	}
+}
+
+func BenchmarkNewV7(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewV7()
+	}
+}
+
+func BenchmarkNewV7Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			NewV7()
+		}
+	})
+}
+
+func TestSetV7Monotonic(t *testing.T) {
+	// a frozen clock makes every UUID a same-millisecond burst
+	clock := FrozenClock(time.Now())
+	generators := []*Generator{
+		NewGenerator(WithClock(clock), WithV7Monotonic()),
+		NewGenerator(WithClock(clock), WithV7Monotonic()),
+		NewGenerator(WithClock(clock)),
+	}
+	generators[2].SetV7Monotonic(true)
+
+	last := Nil
+	for i := 0; i < 1000; i++ {
+		next := generators[i%len(generators)].NewV7()
+		if !last.Less(next) {
+			t.Fatalf("UUID %s is not greater than %s", next, last)
+		}
+		last = next
+	}
+
+	// a generator leaving the mode stays ordered with what it issued
+	g := generators[0]
+	last = g.NewV7()
+	g.SetV7Monotonic(false)
+	if next := g.NewV7(); !last.Less(next) {
+		t.Errorf("UUID %s is not greater than %s", next, last)
+	}
+}
+
+func TestNewV7SubMillisecond(t *testing.T) {
+	SetV7Precision(PrecisionSubMillisecond)
+	defer SetV7Precision(PrecisionMillisecond)
+
+	last := NewV7()
+	for i := 0; i < 10000; i++ {
+		next := NewV7()
+		if next[6]>>4 != 7 {
+			t.Fatalf("incorrect version number detected")
+		}
+		if Compare(last, next) >= 0 {
+			t.Fatalf("UUID out of order on test %d: %s >= %s", i, last,
+				next)
+		}
+		last = next
+	}
+}
+
+func TestV7Precision(t *testing.T) {
+	// 12:00:00.000750500
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 750500, time.UTC)
+	tests := []struct {
+		precision V7Precision
+		fraction  uint64 // expected high bits of rand_a
+		bits      uint
+	}{
+		{PrecisionMillisecond, 0, 0},
+		{Precision250Microsecond, 3, 2},
+		{PrecisionMicrosecond, 768, 10},
+		{PrecisionSubMillisecond, 3074, 12},
+	}
+
+	for _, test := range tests {
+		g := NewGenerator(WithClock(FrozenClock(now)))
+		g.SetV7Precision(test.precision)
+
+		first, second := g.NewV7(), g.NewV7()
+		randA := uint64(first[6]&0x0F)<<8 | uint64(first[7])
+		if fraction := randA >> (12 - test.bits); fraction != test.fraction {
+			t.Errorf("Failed to fill rand_a with precision %d. Expected: %d, "+
+				"Received: %d", test.precision, test.fraction, fraction)
+		}
+		if !first.Less(second) {
+			t.Errorf("UUID %s is not greater than %s", second, first)
+		}
+	}
+}
+
+func TestNewV7At(t *testing.T) {
+	at := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	result := NewV7At(at)
+
+	// the timestamp from RFC 9562 appendix A.6
+	if s := PrintUUID(result); s[:15] != "017f22e2-79b0-7" {
+		t.Errorf("Failed to embed timestamp. Expected: 017f22e2-79b0-7..., "+
+			"Received: %s", s)
+	}
+	if result[8]>>6 != 2 {
+		t.Fatalf("incorrect clock sequence detected")
+	}
+}
+
+func TestNewV7Batch(t *testing.T) {
+	first := NewV7()
+	batch := NewV7Batch(10000)
+	if len(batch) != 10000 {
+		t.Fatalf("incorrect batch size. Expected: %d, Received: %d", 10000,
+			len(batch))
+	}
+
+	last := first
+	for i, next := range batch {
+		if next.Version() != Version7 || next.Variant() != VariantRFC4122 {
+			t.Fatalf("incorrect version or variant detected on test %d", i)
+		}
+		if Compare(last, next) >= 0 {
+			t.Fatalf("UUID out of order on test %d: %s >= %s", i, last,
+				next)
+		}
+		last = next
+	}
+
+	if next := NewV7(); Compare(last, next) >= 0 {
+		t.Errorf("UUID after batch out of order: %s >= %s", last, next)
+	}
+
+	if NewV7Batch(0) != nil {
+		t.Errorf("returned a batch for n = 0")
+	}
+}
+
+func BenchmarkNewV7Batch(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewV7Batch(1000)
+	}
+}
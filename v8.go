@@ -0,0 +1,36 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+// reference https://www.rfc-editor.org/rfc/rfc9562#section-5.8
+
+import (
+	"io"
+)
+
+// NewV8 generates a RFC 9562 Version 8 compliant UUID from custom. Only the
+// version and variant bits are overwritten, the remaining 122 bits are left
+// as provided so applications can embed their own layout. Returns 128-bit /
+// 16 byte array representing the UUID.
+func NewV8(custom [16]byte) UUID {
+	result := make(UUID, 16)
+	copy(result, custom[:])
+	result[6] = (result[6] & 0x0F) | 0x80 // version 8
+	result[8] = (result[8] & 0x3F) | 0x80 // RFC 4122 variant
+
+	return result
+}
+
+// NewV8SHA256 generates a name-based RFC 9562 Version 8 UUID from the first
+// 128 bits of the SHA-256 hash of the namespace UUID and name, as described
+// in RFC 9562 appendix B.2. Use it in place of NewV3 and NewV5 when MD5 and
+// SHA-1 are not acceptable. Returns 128-bit / 16 byte array representing the
+// UUID.
+func NewV8SHA256(namespaceUUID UUID, name string) UUID {
+	hash := NewV8SHA256Hash(namespaceUUID)
+	io.WriteString(hash, name)
+
+	return hash.UUID()
+}
@@ -0,0 +1,53 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestNewV8(t *testing.T) {
+	custom := [16]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	result := NewV8(custom)
+	if result == nil {
+		t.Fatalf("returned a nil byte array")
+	}
+
+	// check version is 8
+	if result[6]>>4 != 8 {
+		t.Fatalf("incorrect version number detected")
+	}
+
+	// check clock sequence bits set correctly
+	if result[8]>>6 != 2 {
+		t.Fatalf("incorrect clock sequence detected")
+	}
+
+	// check the custom bits were left alone
+	expected := "ffffffff-ffff-8fff-bfff-ffffffffffff"
+	if PrintUUID(result) != expected {
+		t.Errorf("Failed to preserve custom bits. Expected: %s, "+
+			"Received: %s", expected, PrintUUID(result))
+	}
+}
+
+func TestNewV8SHA256(t *testing.T) {
+	// test vector from RFC 9562 appendix B.2
+	dns, _ := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	expected := "5c146b14-3c52-8afd-938a-375d0df1fbf6"
+
+	result := NewV8SHA256(dns, "www.example.com")
+	if PrintUUID(result) != expected {
+		t.Errorf("Failed to generate SHA-256 UUID. Expected: %s, "+
+			"Received: %s", expected, PrintUUID(result))
+	}
+
+	if result.Version() != Version8 || result.Variant() != VariantRFC4122 {
+		t.Errorf("incorrect version or variant detected")
+	}
+}
+
+func BenchmarkNewV8SHA256(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewV8SHA256(Namespace(), "test")
+	}
+}
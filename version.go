@@ -0,0 +1,89 @@
+// Copyright 2019 Edward F. Ward III.  All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+)
+
+// Version is the version number stored in the four most significant bits of
+// the time_hi_and_version field.
+type Version byte
+
+const (
+	Version1 Version = 1 // time-based
+	Version2 Version = 2 // DCE security
+	Version3 Version = 3 // name-based using MD5
+	Version4 Version = 4 // random
+	Version5 Version = 5 // name-based using SHA-1
+	Version6 Version = 6 // reordered time-based
+	Version7 Version = 7 // Unix epoch time-based
+	Version8 Version = 8 // custom
+)
+
+// String returns the version as VERSION_n, or BAD_VERSION_n for versions not
+// defined by RFC 4122 and RFC 9562.
+func (v Version) String() string {
+	if v < Version1 || v > Version8 {
+		return fmt.Sprintf("BAD_VERSION_%d", v)
+	}
+
+	return fmt.Sprintf("VERSION_%d", v)
+}
+
+// Variant is the layout of the UUID as determined by the most significant
+// bits of the clock_seq_hi_and_reserved field.
+type Variant byte
+
+const (
+	VariantInvalid   Variant = iota // not a 16 byte UUID
+	VariantNCS                      // 0xx, reserved for NCS compatibility
+	VariantRFC4122                  // 10x, the layout used by this package
+	VariantMicrosoft                // 110, reserved for Microsoft compatibility
+	VariantFuture                   // 111, reserved for future definition
+)
+
+func (v Variant) String() string {
+	switch v {
+	case VariantNCS:
+		return "NCS"
+	case VariantRFC4122:
+		return "RFC4122"
+	case VariantMicrosoft:
+		return "Microsoft"
+	case VariantFuture:
+		return "Future"
+	}
+
+	return "Invalid"
+}
+
+// Version returns the version of uuid. Returns 0 if uuid is not 16 bytes.
+func (uuid UUID) Version() Version {
+	if len(uuid) != 16 {
+		return 0
+	}
+
+	return Version(uuid[6] >> 4)
+}
+
+// Variant returns the variant of uuid. Returns VariantInvalid if uuid is not
+// 16 bytes.
+func (uuid UUID) Variant() Variant {
+	if len(uuid) != 16 {
+		return VariantInvalid
+	}
+
+	switch {
+	case uuid[8]&0x80 == 0x00:
+		return VariantNCS
+	case uuid[8]&0xC0 == 0x80:
+		return VariantRFC4122
+	case uuid[8]&0xE0 == 0xC0:
+		return VariantMicrosoft
+	}
+
+	return VariantFuture
+}
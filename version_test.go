@@ -0,0 +1,47 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	if v := NewV1().Version(); v != Version1 {
+		t.Errorf("incorrect version detected: %s", v)
+	}
+	if v := NewV3(Namespace(), "test").Version(); v != Version3 {
+		t.Errorf("incorrect version detected: %s", v)
+	}
+	if v := NewV4().Version(); v != Version4 {
+		t.Errorf("incorrect version detected: %s", v)
+	}
+	if v := NewV5(Namespace(), "test").Version(); v != Version5 {
+		t.Errorf("incorrect version detected: %s", v)
+	}
+	if v := UUID(nil).Version(); v != 0 {
+		t.Errorf("incorrect version detected for nil slice: %s", v)
+	}
+
+	if Version4.String() != "VERSION_4" || Version(9).String() != "BAD_VERSION_9" {
+		t.Errorf("incorrect version strings: %s, %s", Version4, Version(9))
+	}
+}
+
+func TestVariant(t *testing.T) {
+	if v := NewV4().Variant(); v != VariantRFC4122 {
+		t.Errorf("incorrect variant detected: %s", v)
+	}
+	if v := Nil.Variant(); v != VariantNCS {
+		t.Errorf("incorrect variant detected for Nil: %s", v)
+	}
+	if v := Max.Variant(); v != VariantFuture {
+		t.Errorf("incorrect variant detected for Max: %s", v)
+	}
+
+	microsoft, _ := Parse("6ba7b810-9dad-11d1-c0b4-00c04fd430c8")
+	if v := microsoft.Variant(); v != VariantMicrosoft {
+		t.Errorf("incorrect variant detected: %s", v)
+	}
+	if v := UUID(nil).Variant(); v != VariantInvalid {
+		t.Errorf("incorrect variant detected for nil slice: %s", v)
+	}
+}